@@ -0,0 +1,98 @@
+package main
+
+import "fmt"
+
+type Node struct {
+	Data string
+	Next *Node
+}
+
+// CircularLinkedList is a singly linked list whose tail points back to the head
+type CircularLinkedList struct {
+	Head *Node
+	tail *Node
+}
+
+func NewCircularList() *CircularLinkedList {
+	return &CircularLinkedList{}
+}
+
+// Append adds a node after the tail and links it back to the head
+func (c *CircularLinkedList) Append(data string) {
+	newNode := &Node{Data: data}
+	if c.Head == nil {
+		newNode.Next = newNode // a single node points to itself
+		c.Head = newNode
+		c.tail = newNode
+		return
+	}
+	newNode.Next = c.Head
+	c.tail.Next = newNode
+	c.tail = newNode
+}
+
+// Delete removes the first node holding data and reports whether one was found
+func (c *CircularLinkedList) Delete(data string) bool {
+	if c.Head == nil {
+		return false
+	}
+	prev := c.tail
+	currentNode := c.Head
+	for {
+		if currentNode.Data == data {
+			if currentNode == currentNode.Next { // the only node
+				c.Head = nil
+				c.tail = nil
+				return true
+			}
+			prev.Next = currentNode.Next
+			if currentNode == c.Head {
+				c.Head = currentNode.Next
+			}
+			if currentNode == c.tail {
+				c.tail = prev
+			}
+			return true
+		}
+		prev = currentNode
+		currentNode = currentNode.Next
+		if currentNode == c.Head { // back at the start: walked one full loop
+			return false
+		}
+	}
+}
+
+// ToSlice returns the values from head to tail. It stops when the walk comes
+// back around to the head, so it visits every node exactly once.
+func (c *CircularLinkedList) ToSlice() []string {
+	values := []string{}
+	if c.Head == nil {
+		return values
+	}
+	currentNode := c.Head
+	for {
+		values = append(values, currentNode.Data)
+		currentNode = currentNode.Next
+		if currentNode == c.Head {
+			return values
+		}
+	}
+}
+
+func main() {
+	single := NewCircularList()
+	single.Append("Rahul")
+	fmt.Println("single node points to itself:", single.Head.Next == single.Head, single.ToSlice())
+	fmt.Println("delete only node:", single.Delete("Rahul"), single.ToSlice(), single.Head == nil)
+
+	cl := NewCircularList()
+	for _, name := range []string{"a", "b", "c", "d"} {
+		cl.Append(name)
+	}
+	fmt.Println("list:", cl.ToSlice())
+	fmt.Println("tail links to head:", cl.tail.Next == cl.Head)
+	cl.Delete("a") // head
+	cl.Delete("d") // tail
+	fmt.Println("after deleting head and tail:", cl.ToSlice(), "tail links to head:", cl.tail.Next == cl.Head)
+	fmt.Println("delete missing:", cl.Delete("z"))
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSingleNodePointsToItself(t *testing.T) {
+	c := NewCircularList()
+	c.Append("only")
+
+	if c.Head.Next != c.Head || c.tail != c.Head {
+		t.Error("single node does not link back to itself")
+	}
+	if got, want := c.ToSlice(), []string{"only"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("ToSlice() = %v, want %v", got, want)
+	}
+	if !c.Delete("only") {
+		t.Fatal("Delete(\"only\") = false, want true")
+	}
+	if c.Head != nil || c.tail != nil || len(c.ToSlice()) != 0 {
+		t.Errorf("after deleting the only node: Head = %v, ToSlice() = %v, want an empty list", c.Head, c.ToSlice())
+	}
+}
+
+func TestDeleteKeepsTheLoop(t *testing.T) {
+	tests := []struct {
+		name   string
+		delete string
+		want   []string
+		found  bool
+	}{
+		{name: "head", delete: "a", want: []string{"b", "c", "d"}, found: true},
+		{name: "middle", delete: "c", want: []string{"a", "b", "d"}, found: true},
+		{name: "tail", delete: "d", want: []string{"a", "b", "c"}, found: true},
+		{name: "missing", delete: "z", want: []string{"a", "b", "c", "d"}, found: false},
+	}
+	for _, tt := range tests {
+		c := NewCircularList()
+		for _, v := range []string{"a", "b", "c", "d"} {
+			c.Append(v)
+		}
+		if got := c.Delete(tt.delete); got != tt.found {
+			t.Errorf("%s: Delete(%q) = %v, want %v", tt.name, tt.delete, got, tt.found)
+		}
+		if got := c.ToSlice(); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: ToSlice() = %v, want %v", tt.name, got, tt.want)
+		}
+		if c.tail.Next != c.Head {
+			t.Errorf("%s: tail no longer links to head", tt.name)
+		}
+	}
+}
+
+func TestToSliceEmpty(t *testing.T) {
+	if got := NewCircularList().ToSlice(); got == nil || len(got) != 0 {
+		t.Errorf("ToSlice() on empty list = %#v, want an empty slice", got)
+	}
+}
@@ -100,6 +100,43 @@ func (l *LinkedList) ListValues() {
 	}
 }
 
+func listLength(head *Node) int {
+	length := 0
+	for ; head != nil; head = head.Next {
+		length++
+	}
+	return length
+}
+
+// IntersectionNode returns the node where lists a and b merge, or nil if they
+// never share a node. Nodes are compared by pointer, not by Data: two separate
+// nodes holding the same value are not an intersection. The longer list is
+// advanced by the length difference first so both pointers reach the shared
+// tail together.
+func IntersectionNode(a, b *Node) *Node {
+	lenA, lenB := listLength(a), listLength(b)
+	for ; lenA > lenB; lenA-- {
+		a = a.Next
+	}
+	for ; lenB > lenA; lenB-- {
+		b = b.Next
+	}
+	for a != b {
+		a = a.Next
+		b = b.Next
+	}
+	return a
+}
+
+// buildList links the values into a list ending in tail and returns its head
+func buildList(tail *Node, values ...string) *Node {
+	head := tail
+	for i := len(values) - 1; i >= 0; i-- {
+		head = &Node{Data: values[i], Next: head}
+	}
+	return head
+}
+
 func main() {
 	ll := NewList()
 	ll.AddNodeAtEnd("Rahul")
@@ -109,4 +146,14 @@ func main() {
 	ll.DeleteLastNode()
 	ll.ListValues()
 
+	shared := buildList(nil, "x", "y")
+	first := buildList(shared, "a", "b", "c")
+	second := buildList(shared, "p")
+	fmt.Println("intersection:", IntersectionNode(first, second).Data) // x
+
+	// Same values, different nodes: not an intersection
+	lookalike := buildList(nil, "p", "x", "y")
+	fmt.Println("value-equal lists intersect:", IntersectionNode(first, lookalike) != nil) // false
+	fmt.Println("empty list:", IntersectionNode(nil, first))
+
 }
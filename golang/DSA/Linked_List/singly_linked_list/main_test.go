@@ -0,0 +1,56 @@
+package main
+
+import "testing"
+
+// sharedTailLists builds two lists that both end in the nodes of tail
+func sharedTailLists(aValues, bValues, tail []string) (a, b, shared *Node) {
+	shared = buildList(nil, tail...)
+	return buildList(shared, aValues...), buildList(shared, bValues...), shared
+}
+
+func TestIntersectionNode(t *testing.T) {
+	tests := []struct {
+		name    string
+		a, b    []string
+		tail    []string
+		wantNil bool
+	}{
+		{name: "a longer", a: []string{"a", "b", "c"}, b: []string{"p"}, tail: []string{"x", "y"}},
+		{name: "b longer", a: []string{"a"}, b: []string{"p", "q", "r", "s"}, tail: []string{"x"}},
+		{name: "same length", a: []string{"a", "b"}, b: []string{"p", "q"}, tail: []string{"x"}},
+		{name: "b is the tail", a: []string{"a", "b"}, b: nil, tail: []string{"x", "y"}},
+		{name: "no shared nodes", a: []string{"a"}, b: []string{"p"}, tail: nil, wantNil: true},
+	}
+	for _, tt := range tests {
+		a, b, shared := sharedTailLists(tt.a, tt.b, tt.tail)
+		got := IntersectionNode(a, b)
+		if tt.wantNil {
+			if got != nil {
+				t.Errorf("%s: IntersectionNode = %v, want nil", tt.name, got)
+			}
+			continue
+		}
+		if got != shared {
+			t.Errorf("%s: IntersectionNode = %p, want the first shared node %p", tt.name, got, shared)
+		}
+	}
+}
+
+func TestIntersectionNodeComparesPointers(t *testing.T) {
+	// Both lists end in "x" -> "y", but as separate nodes
+	a := buildList(nil, "a", "x", "y")
+	b := buildList(nil, "p", "x", "y")
+	if got := IntersectionNode(a, b); got != nil {
+		t.Errorf("IntersectionNode of value-equal tails = %v, want nil", got.Data)
+	}
+}
+
+func TestIntersectionNodeEmptyList(t *testing.T) {
+	list := buildList(nil, "a", "b")
+	if got := IntersectionNode(nil, list); got != nil {
+		t.Errorf("IntersectionNode(nil, list) = %v, want nil", got)
+	}
+	if got := IntersectionNode(list, list); got != list {
+		t.Errorf("IntersectionNode(list, list) = %p, want the head %p", got, list)
+	}
+}
@@ -118,6 +118,278 @@ func FindOutNodeCount(root *TreeNode) int {
 	return 1 + FindOutNodeCount(root.LeftNode) + FindOutNodeCount(root.RightNode)
 }
 
+// Min returns the smallest value in the BST by walking to the leftmost node.
+// It returns false for an empty tree.
+func Min(root *TreeNode) (int, bool) {
+	if root == nil {
+		return 0, false
+	}
+	for root.LeftNode != nil {
+		root = root.LeftNode
+	}
+	return root.Data, true
+}
+
+// Max returns the largest value in the BST by walking to the rightmost node.
+// It returns false for an empty tree.
+func Max(root *TreeNode) (int, bool) {
+	if root == nil {
+		return 0, false
+	}
+	for root.RightNode != nil {
+		root = root.RightNode
+	}
+	return root.Data, true
+}
+
+// Sum returns the sum of every node's value
+func Sum(root *TreeNode) int {
+	if root == nil {
+		return 0
+	}
+	return root.Data + Sum(root.LeftNode) + Sum(root.RightNode)
+}
+
+// KthSmallest returns the k-th smallest value (1-based) in the BST. It walks
+// the tree in order with an explicit stack and stops as soon as k nodes have
+// been visited. It returns false if k is not positive or exceeds the node count.
+func KthSmallest(root *TreeNode, k int) (int, bool) {
+	if k <= 0 {
+		return 0, false
+	}
+	var stack []*TreeNode
+	current := root
+	for current != nil || len(stack) > 0 {
+		for current != nil {
+			stack = append(stack, current)
+			current = current.LeftNode
+		}
+		current = stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		k--
+		if k == 0 {
+			return current.Data, true
+		}
+		current = current.RightNode
+	}
+	return 0, false
+}
+
+// RightSideView returns the last node of each level, top to bottom: what you
+// see looking at the tree from the right. A level's last node can be a left
+// child when nothing lies to its right.
+func RightSideView(root *TreeNode) []int {
+	view := []int{}
+	if root == nil {
+		return view
+	}
+	level := []*TreeNode{root}
+	for len(level) > 0 {
+		view = append(view, level[len(level)-1].Data)
+		var next []*TreeNode
+		for _, node := range level {
+			if node.LeftNode != nil {
+				next = append(next, node.LeftNode)
+			}
+			if node.RightNode != nil {
+				next = append(next, node.RightNode)
+			}
+		}
+		level = next
+	}
+	return view
+}
+
+// BuildBalancedBST builds a height-balanced BST from an ascending slice by
+// making the middle element the root and building each half the same way
+func BuildBalancedBST(sorted []int) *TreeNode {
+	if len(sorted) == 0 {
+		return nil
+	}
+	mid := len(sorted) / 2
+	return &TreeNode{
+		LeftNode:  BuildBalancedBST(sorted[:mid]),
+		Data:      sorted[mid],
+		RightNode: BuildBalancedBST(sorted[mid+1:]),
+	}
+}
+
+// IsBalanced reports whether the heights of every node's two subtrees differ by at most one
+func IsBalanced(root *TreeNode) bool {
+	return balancedHeight(root) >= -1
+}
+
+// balancedHeight returns the tree height, or -2 if some subtree is unbalanced
+func balancedHeight(root *TreeNode) int {
+	if root == nil {
+		return -1
+	}
+	left := balancedHeight(root.LeftNode)
+	right := balancedHeight(root.RightNode)
+	if left == -2 || right == -2 || left-right > 1 || right-left > 1 {
+		return -2
+	}
+	return max(left, right) + 1
+}
+
+// InOrderValues returns the values in in-order (Left → Root → Right)
+func InOrderValues(root *TreeNode) []int {
+	values := []int{}
+	var walk func(node *TreeNode)
+	walk = func(node *TreeNode) {
+		if node != nil {
+			walk(node.LeftNode)
+			values = append(values, node.Data)
+			walk(node.RightNode)
+		}
+	}
+	walk(root)
+	return values
+}
+
+// PathsWithSum returns every root-to-leaf path whose values add up to target.
+// The running path slice is shared while walking, so each match is copied.
+func PathsWithSum(root *TreeNode, target int) [][]int {
+	paths := [][]int{}
+	var path []int
+	var walk func(node *TreeNode, sum int)
+	walk = func(node *TreeNode, sum int) {
+		if node == nil {
+			return
+		}
+		path = append(path, node.Data)
+		sum += node.Data
+		if node.LeftNode == nil && node.RightNode == nil && sum == target {
+			paths = append(paths, append([]int(nil), path...))
+		}
+		walk(node.LeftNode, sum)
+		walk(node.RightNode, sum)
+		path = path[:len(path)-1]
+	}
+	walk(root, 0)
+	return paths
+}
+
+// Successor returns the node holding the next larger value after value in the
+// BST. It returns false if value isn't in the tree or is the largest value.
+func Successor(root *TreeNode, value int) (*TreeNode, bool) {
+	var candidate *TreeNode // smallest ancestor seen that is larger than value
+	node := root
+	for node != nil && node.Data != value {
+		if value < node.Data {
+			candidate = node
+			node = node.LeftNode
+		} else {
+			node = node.RightNode
+		}
+	}
+	if node == nil {
+		return nil, false
+	}
+	if node.RightNode != nil {
+		next := node.RightNode
+		for next.LeftNode != nil {
+			next = next.LeftNode
+		}
+		return next, true
+	}
+	return candidate, candidate != nil
+}
+
+// Predecessor returns the node holding the next smaller value before value in
+// the BST. It returns false if value isn't in the tree or is the smallest value.
+func Predecessor(root *TreeNode, value int) (*TreeNode, bool) {
+	var candidate *TreeNode // largest ancestor seen that is smaller than value
+	node := root
+	for node != nil && node.Data != value {
+		if value < node.Data {
+			node = node.LeftNode
+		} else {
+			candidate = node
+			node = node.RightNode
+		}
+	}
+	if node == nil {
+		return nil, false
+	}
+	if node.LeftNode != nil {
+		prev := node.LeftNode
+		for prev.RightNode != nil {
+			prev = prev.RightNode
+		}
+		return prev, true
+	}
+	return candidate, candidate != nil
+}
+
+// VerticalOrder groups node values by column, where the root is column 0, a
+// left child is one column left of its parent and a right child one column
+// right. Columns are returned left to right. A BFS carries each node's column,
+// so values within a column are top to bottom, and nodes sharing a row and
+// column appear left to right.
+func VerticalOrder(root *TreeNode) [][]int {
+	columns := [][]int{}
+	if root == nil {
+		return columns
+	}
+	type item struct {
+		node   *TreeNode
+		column int
+	}
+	byColumn := make(map[int][]int)
+	minColumn, maxColumn := 0, 0
+	queue := []item{{root, 0}}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		byColumn[current.column] = append(byColumn[current.column], current.node.Data)
+		minColumn = min(minColumn, current.column)
+		maxColumn = max(maxColumn, current.column)
+		if current.node.LeftNode != nil {
+			queue = append(queue, item{current.node.LeftNode, current.column - 1})
+		}
+		if current.node.RightNode != nil {
+			queue = append(queue, item{current.node.RightNode, current.column + 1})
+		}
+	}
+	for c := minColumn; c <= maxColumn; c++ {
+		columns = append(columns, byColumn[c])
+	}
+	return columns
+}
+
+// MorrisInOrder returns the in-order values using O(1) extra space. Instead of
+// a stack, each node's in-order predecessor gets a temporary right link
+// ("thread") back to the node, which is followed and then removed on the
+// second visit, so the tree is left exactly as it was found.
+func MorrisInOrder(root *TreeNode) []int {
+	values := []int{}
+	current := root
+	for current != nil {
+		if current.LeftNode == nil {
+			values = append(values, current.Data)
+			current = current.RightNode
+			continue
+		}
+		// Find the rightmost node of the left subtree
+		predecessor := current.LeftNode
+		for predecessor.RightNode != nil && predecessor.RightNode != current {
+			predecessor = predecessor.RightNode
+		}
+		if predecessor.RightNode == nil {
+			// First visit: thread back to current and go left
+			predecessor.RightNode = current
+			current = current.LeftNode
+		} else {
+			// Second visit: the left subtree is done, remove the thread
+			predecessor.RightNode = nil
+			values = append(values, current.Data)
+			current = current.RightNode
+		}
+	}
+	return values
+}
+
 func main() {
 
 	Root := &TreeNode{}
@@ -136,4 +408,98 @@ func main() {
 	fmt.Println("Total Node", FindOutNodeCount(Root))
 	fmt.Println("Check IfT wo Trees Are Identical", CheckIfTwoTreesAreIdentical(Root, Root.LeftNode))
 
+	var negatives *TreeNode
+	for _, v := range []int{-5, -10, 3, -1, 8} {
+		negatives = Insert(negatives, v)
+	}
+	minValue, _ := Min(negatives)
+	maxValue, _ := Max(negatives)
+	fmt.Println("Min", minValue, "Max", maxValue, "Sum", Sum(negatives)) // -10 8 -5
+	single := &TreeNode{Data: 42}
+	minValue, _ = Min(single)
+	maxValue, _ = Max(single)
+	fmt.Println("Single node Min", minValue, "Max", maxValue, "Sum", Sum(single))
+	_, ok := Min(nil)
+	fmt.Println("Empty tree Min ok", ok, "Sum", Sum(nil))
+
+	var balanced, skewed *TreeNode
+	for _, v := range []int{4, 2, 6, 1, 3, 5, 7} {
+		balanced = Insert(balanced, v)
+	}
+	for v := 1; v <= 5; v++ {
+		skewed = Insert(skewed, v) // every node only has a right child
+	}
+	for _, k := range []int{1, 4, 7, 8, 0} {
+		value, ok := KthSmallest(balanced, k)
+		fmt.Println("Balanced KthSmallest", k, value, ok)
+	}
+	for _, k := range []int{1, 5, 6} {
+		value, ok := KthSmallest(skewed, k)
+		fmt.Println("Skewed KthSmallest", k, value, ok)
+	}
+
+	//        10
+	//       /  \
+	//      5    15
+	//     /
+	//    3
+	//   /
+	//  1
+	var asymmetric *TreeNode
+	for _, v := range []int{10, 5, 15, 3, 1} {
+		asymmetric = Insert(asymmetric, v)
+	}
+	fmt.Println("Right side view", RightSideView(asymmetric)) // [10 15 3 1]
+
+	for _, sorted := range [][]int{{}, {7}, {1, 2, 3, 4, 5, 6, 7, 8, 9, 10}} {
+		built := BuildBalancedBST(sorted)
+		fmt.Println("Balanced BST from", sorted, "balanced", IsBalanced(built),
+			"in-order", InOrderValues(built), "height", GetTreeHeight(built))
+	}
+	fmt.Println("Skewed tree balanced", IsBalanced(skewed)) // false
+
+	//        5
+	//      /   \
+	//    -3     8
+	//    / \   / \
+	//  -4   2 6   9
+	pathTree := &TreeNode{
+		Data:      5,
+		LeftNode:  &TreeNode{Data: -3, LeftNode: &TreeNode{Data: -4}, RightNode: &TreeNode{Data: 2}},
+		RightNode: &TreeNode{Data: 8, LeftNode: &TreeNode{Data: 6}, RightNode: &TreeNode{Data: 9}},
+	}
+	fmt.Println("Paths with sum -2", PathsWithSum(pathTree, -2)) // [[5 -3 -4]]
+	fmt.Println("Paths with sum 4", PathsWithSum(pathTree, 4))   // [[5 -3 2]]
+	fmt.Println("Paths with sum 100", PathsWithSum(pathTree, 100), "nil tree", PathsWithSum(nil, 0))
+
+	//          20
+	//        /    \
+	//       8      22
+	//      / \
+	//     4   12
+	//        /  \
+	//       10   14
+	var bst *TreeNode
+	for _, v := range []int{20, 8, 22, 4, 12, 10, 14} {
+		bst = Insert(bst, v)
+	}
+	for _, v := range []int{4, 8, 10, 14, 20, 22, 13} {
+		next, hasNext := Successor(bst, v)
+		prev, hasPrev := Predecessor(bst, v)
+		nextValue, prevValue := "none", "none"
+		if hasNext {
+			nextValue = fmt.Sprint(next.Data)
+		}
+		if hasPrev {
+			prevValue = fmt.Sprint(prev.Data)
+		}
+		fmt.Println("Value", v, "successor", nextValue, "predecessor", prevValue)
+	}
+
+	// In the tree above 12 (left then right of the root) shares column 0 with 20
+	fmt.Println("Vertical order", VerticalOrder(bst)) // [[4] [8 10] [20 12] [22 14]]
+
+	fmt.Println("Morris in-order", MorrisInOrder(bst)) // [4 8 10 12 14 20 22]
+	fmt.Println("Morris on nil tree", MorrisInOrder(nil))
+
 }
@@ -0,0 +1,298 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+// buildBST inserts values in order into an empty BST
+func buildBST(values ...int) *TreeNode {
+	var root *TreeNode
+	for _, v := range values {
+		root = Insert(root, v)
+	}
+	return root
+}
+
+func TestAggregates(t *testing.T) {
+	tests := []struct {
+		name    string
+		root    *TreeNode
+		wantMin int
+		wantMax int
+		wantSum int
+		wantOk  bool
+	}{
+		{name: "empty", root: nil, wantSum: 0, wantOk: false},
+		{name: "single node", root: &TreeNode{Data: 42}, wantMin: 42, wantMax: 42, wantSum: 42, wantOk: true},
+		{name: "negatives", root: buildBST(-5, -10, 3, -1, 8), wantMin: -10, wantMax: 8, wantSum: -5, wantOk: true},
+		{name: "all negative", root: buildBST(-2, -7, -1), wantMin: -7, wantMax: -1, wantSum: -10, wantOk: true},
+	}
+	for _, tt := range tests {
+		minValue, minOk := Min(tt.root)
+		maxValue, maxOk := Max(tt.root)
+		if minOk != tt.wantOk || maxOk != tt.wantOk {
+			t.Errorf("%s: Min/Max ok = %v/%v, want %v", tt.name, minOk, maxOk, tt.wantOk)
+		}
+		if tt.wantOk && (minValue != tt.wantMin || maxValue != tt.wantMax) {
+			t.Errorf("%s: Min, Max = %d, %d, want %d, %d", tt.name, minValue, maxValue, tt.wantMin, tt.wantMax)
+		}
+		if got := Sum(tt.root); got != tt.wantSum {
+			t.Errorf("%s: Sum = %d, want %d", tt.name, got, tt.wantSum)
+		}
+	}
+}
+
+func TestKthSmallest(t *testing.T) {
+	balanced := buildBST(4, 2, 6, 1, 3, 5, 7)
+	skewed := buildBST(1, 2, 3, 4, 5) // every node only has a right child
+
+	tests := []struct {
+		name   string
+		root   *TreeNode
+		k      int
+		want   int
+		wantOk bool
+	}{
+		{name: "balanced first", root: balanced, k: 1, want: 1, wantOk: true},
+		{name: "balanced middle", root: balanced, k: 4, want: 4, wantOk: true},
+		{name: "balanced last", root: balanced, k: 7, want: 7, wantOk: true},
+		{name: "balanced past the end", root: balanced, k: 8},
+		{name: "zero", root: balanced, k: 0},
+		{name: "negative", root: balanced, k: -1},
+		{name: "skewed first", root: skewed, k: 1, want: 1, wantOk: true},
+		{name: "skewed last", root: skewed, k: 5, want: 5, wantOk: true},
+		{name: "skewed past the end", root: skewed, k: 6},
+		{name: "empty", root: nil, k: 1},
+	}
+	for _, tt := range tests {
+		got, ok := KthSmallest(tt.root, tt.k)
+		if ok != tt.wantOk || got != tt.want {
+			t.Errorf("%s: KthSmallest(%d) = %d, %v, want %d, %v", tt.name, tt.k, got, ok, tt.want, tt.wantOk)
+		}
+	}
+}
+
+func TestRightSideView(t *testing.T) {
+	tests := []struct {
+		name string
+		root *TreeNode
+		want []int
+	}{
+		{name: "empty", root: nil, want: []int{}},
+		{name: "single node", root: &TreeNode{Data: 1}, want: []int{1}},
+		//        10
+		//       /  \
+		//      5    15
+		//     /
+		//    3
+		//   /
+		//  1
+		{name: "left-heavy", root: buildBST(10, 5, 15, 3, 1), want: []int{10, 15, 3, 1}},
+		{name: "full", root: buildBST(4, 2, 6, 1, 3, 5, 7), want: []int{4, 6, 7}},
+	}
+	for _, tt := range tests {
+		if got := RightSideView(tt.root); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: RightSideView = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestBuildBalancedBST(t *testing.T) {
+	inputs := [][]int{
+		{},
+		{7},
+		{1, 2},
+		{1, 2, 3, 4, 5, 6, 7, 8, 9, 10},
+	}
+	for _, sorted := range inputs {
+		root := BuildBalancedBST(sorted)
+		if !IsBalanced(root) {
+			t.Errorf("BuildBalancedBST(%v) is not balanced", sorted)
+		}
+		if got := InOrderValues(root); !reflect.DeepEqual(got, sorted) {
+			t.Errorf("BuildBalancedBST(%v) in-order = %v", sorted, got)
+		}
+	}
+	if root := BuildBalancedBST(nil); root != nil {
+		t.Errorf("BuildBalancedBST(nil) = %v, want nil", root)
+	}
+}
+
+func TestIsBalancedRejectsSkewedTree(t *testing.T) {
+	if IsBalanced(buildBST(1, 2, 3)) {
+		t.Error("IsBalanced(1 -> 2 -> 3) = true, want false")
+	}
+}
+
+func TestPathsWithSum(t *testing.T) {
+	//        5
+	//      /   \
+	//    -3     8
+	//    / \   / \
+	//  -4   2 6   9
+	root := &TreeNode{
+		Data:      5,
+		LeftNode:  &TreeNode{Data: -3, LeftNode: &TreeNode{Data: -4}, RightNode: &TreeNode{Data: 2}},
+		RightNode: &TreeNode{Data: 8, LeftNode: &TreeNode{Data: 6}, RightNode: &TreeNode{Data: 9}},
+	}
+	// Two leaves reach the same sum, so both paths must come back intact
+	twoPaths := &TreeNode{
+		Data:      1,
+		LeftNode:  &TreeNode{Data: 2, LeftNode: &TreeNode{Data: 3}, RightNode: &TreeNode{Data: 3}},
+		RightNode: &TreeNode{Data: 5},
+	}
+
+	tests := []struct {
+		name   string
+		root   *TreeNode
+		target int
+		want   [][]int
+	}{
+		{name: "negative sum", root: root, target: -2, want: [][]int{{5, -3, -4}}},
+		{name: "through a negative node", root: root, target: 4, want: [][]int{{5, -3, 2}}},
+		{name: "right side", root: root, target: 22, want: [][]int{{5, 8, 9}}},
+		{name: "several matches", root: twoPaths, target: 6, want: [][]int{{1, 2, 3}, {1, 2, 3}, {1, 5}}},
+		{name: "no match", root: root, target: 100, want: [][]int{}},
+		{name: "inner node sum only", root: root, target: 2, want: [][]int{}},
+		{name: "empty tree", root: nil, target: 0, want: [][]int{}},
+	}
+	for _, tt := range tests {
+		if got := PathsWithSum(tt.root, tt.target); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: PathsWithSum(%d) = %v, want %v", tt.name, tt.target, got, tt.want)
+		}
+	}
+}
+
+// newSuccessorTree builds
+//
+//	     20
+//	   /    \
+//	  8      22
+//	 / \
+//	4   12
+//	   /  \
+//	  10   14
+func newSuccessorTree() *TreeNode {
+	return buildBST(20, 8, 22, 4, 12, 10, 14)
+}
+
+func TestSuccessorAndPredecessor(t *testing.T) {
+	root := newSuccessorTree()
+	tests := []struct {
+		value      int
+		next, prev int // -1 for none
+	}{
+		{value: 4, next: 8, prev: -1},   // minimum
+		{value: 8, next: 10, prev: 4},   // successor in the right subtree
+		{value: 10, next: 12, prev: 8},  // predecessor is an ancestor
+		{value: 14, next: 20, prev: 12}, // successor is an ancestor
+		{value: 20, next: 22, prev: 14},
+		{value: 22, next: -1, prev: 20}, // maximum
+		{value: 13, next: -1, prev: -1}, // absent
+	}
+	for _, tt := range tests {
+		next, ok := Successor(root, tt.value)
+		if got := nodeValue(next, ok); got != tt.next {
+			t.Errorf("Successor(%d) = %d, want %d", tt.value, got, tt.next)
+		}
+		prev, ok := Predecessor(root, tt.value)
+		if got := nodeValue(prev, ok); got != tt.prev {
+			t.Errorf("Predecessor(%d) = %d, want %d", tt.value, got, tt.prev)
+		}
+	}
+
+	if _, ok := Successor(nil, 1); ok {
+		t.Error("Successor on a nil tree found a node")
+	}
+	if _, ok := Predecessor(nil, 1); ok {
+		t.Error("Predecessor on a nil tree found a node")
+	}
+}
+
+// nodeValue returns the node's value, or -1 if none was found
+func nodeValue(node *TreeNode, ok bool) int {
+	if !ok {
+		return -1
+	}
+	return node.Data
+}
+
+func TestVerticalOrder(t *testing.T) {
+	tests := []struct {
+		name string
+		root *TreeNode
+		want [][]int
+	}{
+		// 12 is left then right of the root, so it shares column 0 with 20
+		// one level down, and 10 shares column -1 with 8
+		{name: "shared columns", root: newSuccessorTree(), want: [][]int{{4}, {8, 10}, {20, 12}, {22, 14}}},
+		{name: "single node", root: &TreeNode{Data: 1}, want: [][]int{{1}}},
+		{name: "right spine", root: buildBST(1, 2, 3), want: [][]int{{1}, {2}, {3}}},
+		{name: "nil", root: nil, want: [][]int{}},
+	}
+	for _, tt := range tests {
+		if got := VerticalOrder(tt.root); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: VerticalOrder() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestVerticalOrderSameRowAndColumn(t *testing.T) {
+	//       1
+	//     /   \
+	//    2     3
+	//     \   /
+	//      4 5
+	// 4 and 5 are both in column 0 on the same row; 4 is further left
+	root := &TreeNode{
+		Data:      1,
+		LeftNode:  &TreeNode{Data: 2, RightNode: &TreeNode{Data: 4}},
+		RightNode: &TreeNode{Data: 3, LeftNode: &TreeNode{Data: 5}},
+	}
+	want := [][]int{{2}, {1, 4, 5}, {3}}
+	if got := VerticalOrder(root); !reflect.DeepEqual(got, want) {
+		t.Errorf("VerticalOrder() = %v, want %v", got, want)
+	}
+}
+
+// childLinks records every node's child pointers so a traversal can be
+// checked for leaving the tree untouched
+func childLinks(root *TreeNode) map[*TreeNode][2]*TreeNode {
+	links := make(map[*TreeNode][2]*TreeNode)
+	var walk func(node *TreeNode)
+	walk = func(node *TreeNode) {
+		if node == nil {
+			return
+		}
+		links[node] = [2]*TreeNode{node.LeftNode, node.RightNode}
+		walk(node.LeftNode)
+		walk(node.RightNode)
+	}
+	walk(root)
+	return links
+}
+
+func TestMorrisInOrder(t *testing.T) {
+	trees := []struct {
+		name string
+		root *TreeNode
+	}{
+		{name: "multi-level", root: newSuccessorTree()},
+		{name: "left spine", root: buildBST(5, 4, 3, 2, 1)},
+		{name: "right spine", root: buildBST(1, 2, 3, 4, 5)},
+		{name: "balanced", root: BuildBalancedBST([]int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10})},
+		{name: "single node", root: &TreeNode{Data: 1}},
+		{name: "nil", root: nil},
+	}
+	for _, tt := range trees {
+		before := childLinks(tt.root)
+		got := MorrisInOrder(tt.root)
+		if want := InOrderValues(tt.root); !reflect.DeepEqual(got, want) {
+			t.Errorf("%s: MorrisInOrder() = %v, want %v", tt.name, got, want)
+		}
+		if after := childLinks(tt.root); !reflect.DeepEqual(after, before) {
+			t.Errorf("%s: child pointers changed by the traversal", tt.name)
+		}
+	}
+}
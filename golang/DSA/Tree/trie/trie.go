@@ -0,0 +1,136 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+)
+
+type trieNode struct {
+	children map[rune]*trieNode
+	isWord   bool // a word ends at this node
+}
+
+func newTrieNode() *trieNode {
+	return &trieNode{children: make(map[rune]*trieNode)}
+}
+
+// Trie is a prefix tree of words, with one node per character (rune)
+type Trie struct {
+	root *trieNode
+}
+
+func NewTrie() *Trie {
+	return &Trie{root: newTrieNode()}
+}
+
+// Insert adds word to the trie
+func (t *Trie) Insert(word string) {
+	node := t.root
+	for _, r := range word {
+		child, ok := node.children[r]
+		if !ok {
+			child = newTrieNode()
+			node.children[r] = child
+		}
+		node = child
+	}
+	node.isWord = true
+}
+
+// find returns the node reached by following prefix, or nil
+func (t *Trie) find(prefix string) *trieNode {
+	node := t.root
+	for _, r := range prefix {
+		node = node.children[r]
+		if node == nil {
+			return nil
+		}
+	}
+	return node
+}
+
+// Search reports whether word was inserted as a whole word
+func (t *Trie) Search(word string) bool {
+	node := t.find(word)
+	return node != nil && node.isWord
+}
+
+// StartsWith reports whether any inserted word begins with prefix
+func (t *Trie) StartsWith(prefix string) bool {
+	return t.find(prefix) != nil
+}
+
+// WordsWithPrefix returns every inserted word beginning with prefix, sorted
+func (t *Trie) WordsWithPrefix(prefix string) []string {
+	words := []string{}
+	node := t.find(prefix)
+	if node == nil {
+		return words
+	}
+	var collect func(n *trieNode, path []rune)
+	collect = func(n *trieNode, path []rune) {
+		if n.isWord {
+			words = append(words, string(path))
+		}
+		for r, child := range n.children {
+			collect(child, append(path, r))
+		}
+	}
+	collect(node, []rune(prefix))
+	sort.Strings(words)
+	return words
+}
+
+// Delete removes word and reports whether it was present. Nodes left with no
+// children that don't end another word are pruned, so deleting "car" keeps
+// "cart" intact and deleting "cart" alone removes the whole branch.
+func (t *Trie) Delete(word string) bool {
+	runes := []rune(word)
+	var remove func(n *trieNode, depth int) (found, prune bool)
+	remove = func(n *trieNode, depth int) (bool, bool) {
+		if depth == len(runes) {
+			if !n.isWord {
+				return false, false
+			}
+			n.isWord = false
+			return true, len(n.children) == 0
+		}
+		child := n.children[runes[depth]]
+		if child == nil {
+			return false, false
+		}
+		found, prune := remove(child, depth+1)
+		if prune {
+			delete(n.children, runes[depth])
+		}
+		return found, found && !n.isWord && len(n.children) == 0
+	}
+	found, _ := remove(t.root, 0)
+	return found
+}
+
+func main() {
+	trie := NewTrie()
+	for _, word := range []string{"car", "cart", "care", "dog", "dot"} {
+		trie.Insert(word)
+	}
+
+	fmt.Println("Search(car):", trie.Search("car"))         // true
+	fmt.Println("Search(ca):", trie.Search("ca"))           // false: only a prefix
+	fmt.Println("StartsWith(ca):", trie.StartsWith("ca"))   // true
+	fmt.Println("StartsWith(cow):", trie.StartsWith("cow")) // false
+	fmt.Println("WordsWithPrefix(car):", trie.WordsWithPrefix("car"))
+	fmt.Println("WordsWithPrefix(do):", trie.WordsWithPrefix("do"))
+	fmt.Println("WordsWithPrefix(x):", trie.WordsWithPrefix("x"))
+
+	// "car" is a prefix of "cart" and "care": deleting it must keep them
+	fmt.Println("Delete(car):", trie.Delete("car"))
+	fmt.Println("Search(car):", trie.Search("car"), "Search(cart):", trie.Search("cart"), "Search(care):", trie.Search("care"))
+	fmt.Println("Delete(car) again:", trie.Delete("car"))
+	fmt.Println("Delete(ca):", trie.Delete("ca")) // a prefix, never a word
+
+	// Deleting the last words under "do" prunes the branch
+	trie.Delete("dog")
+	trie.Delete("dot")
+	fmt.Println("StartsWith(d) after deleting dog and dot:", trie.StartsWith("d"))
+}
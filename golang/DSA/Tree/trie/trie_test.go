@@ -0,0 +1,91 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+// newTestTrie holds car, cart, care, dog and dot
+func newTestTrie() *Trie {
+	trie := NewTrie()
+	for _, word := range []string{"car", "cart", "care", "dog", "dot"} {
+		trie.Insert(word)
+	}
+	return trie
+}
+
+func TestSearchVersusStartsWith(t *testing.T) {
+	trie := newTestTrie()
+	tests := []struct {
+		s              string
+		wantSearch     bool
+		wantStartsWith bool
+	}{
+		{"car", true, true},
+		{"ca", false, true}, // a prefix, not a word
+		{"cart", true, true},
+		{"carts", false, false},
+		{"cow", false, false},
+		{"", false, true},
+	}
+	for _, tt := range tests {
+		if got := trie.Search(tt.s); got != tt.wantSearch {
+			t.Errorf("Search(%q) = %v, want %v", tt.s, got, tt.wantSearch)
+		}
+		if got := trie.StartsWith(tt.s); got != tt.wantStartsWith {
+			t.Errorf("StartsWith(%q) = %v, want %v", tt.s, got, tt.wantStartsWith)
+		}
+	}
+}
+
+func TestWordsWithPrefix(t *testing.T) {
+	trie := newTestTrie()
+	tests := []struct {
+		prefix string
+		want   []string
+	}{
+		{"car", []string{"car", "care", "cart"}},
+		{"do", []string{"dog", "dot"}},
+		{"", []string{"car", "care", "cart", "dog", "dot"}},
+		{"x", []string{}},
+	}
+	for _, tt := range tests {
+		if got := trie.WordsWithPrefix(tt.prefix); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("WordsWithPrefix(%q) = %v, want %v", tt.prefix, got, tt.want)
+		}
+	}
+}
+
+func TestDeleteWordThatIsAPrefix(t *testing.T) {
+	trie := newTestTrie()
+	if !trie.Delete("car") {
+		t.Fatal("Delete(car) = false, want true")
+	}
+	if trie.Search("car") {
+		t.Error("Search(car) = true after deleting it")
+	}
+	if !trie.Search("cart") || !trie.Search("care") {
+		t.Error("deleting car removed the longer words built on it")
+	}
+	if trie.Delete("car") {
+		t.Error("Delete(car) a second time = true, want false")
+	}
+	if trie.Delete("ca") {
+		t.Error("Delete(ca) = true for a prefix that was never a word")
+	}
+}
+
+func TestDeletePrunesEmptyBranches(t *testing.T) {
+	trie := newTestTrie()
+	trie.Delete("dog")
+	if !trie.StartsWith("do") {
+		t.Error("deleting dog pruned the branch dot still needs")
+	}
+	trie.Delete("dot")
+	if trie.StartsWith("d") {
+		t.Error("StartsWith(d) = true after deleting every d word")
+	}
+	if _, ok := trie.root.children['d']; ok {
+		t.Error("empty d branch was not pruned from the root")
+	}
+}
@@ -0,0 +1,87 @@
+package main
+
+import "fmt"
+
+// DisjointSet (union-find) tracks which elements belong to the same set.
+// Elements are added the first time they are passed to any method, each
+// starting in a set of its own.
+type DisjointSet struct {
+	parent map[int]int
+	rank   map[int]int // upper bound on the height of each root's tree
+	sets   int
+}
+
+func NewDisjointSet() *DisjointSet {
+	return &DisjointSet{parent: make(map[int]int), rank: make(map[int]int)}
+}
+
+// Find returns the representative of x's set. Every node on the way is
+// pointed straight at the root (path compression), so later calls are faster.
+func (d *DisjointSet) Find(x int) int {
+	if _, ok := d.parent[x]; !ok {
+		d.parent[x] = x
+		d.sets++
+		return x
+	}
+	root := x
+	for d.parent[root] != root {
+		root = d.parent[root]
+	}
+	for x != root {
+		next := d.parent[x]
+		d.parent[x] = root
+		x = next
+	}
+	return root
+}
+
+// Union merges the sets holding a and b, attaching the shorter tree under the
+// taller one (union by rank). It does nothing if they are already connected.
+func (d *DisjointSet) Union(a, b int) {
+	rootA, rootB := d.Find(a), d.Find(b)
+	if rootA == rootB {
+		return
+	}
+	switch {
+	case d.rank[rootA] < d.rank[rootB]:
+		d.parent[rootA] = rootB
+	case d.rank[rootA] > d.rank[rootB]:
+		d.parent[rootB] = rootA
+	default:
+		d.parent[rootB] = rootA
+		d.rank[rootA]++
+	}
+	d.sets--
+}
+
+// Connected reports whether a and b are in the same set
+func (d *DisjointSet) Connected(a, b int) bool {
+	return d.Find(a) == d.Find(b)
+}
+
+// SetCount returns the number of disjoint sets
+func (d *DisjointSet) SetCount() int {
+	return d.sets
+}
+
+func main() {
+	ds := NewDisjointSet()
+	for i := 1; i <= 6; i++ {
+		ds.Find(i) // add 1..6 as singletons
+	}
+	fmt.Println("sets:", ds.SetCount()) // 6
+
+	ds.Union(1, 2)
+	ds.Union(2, 3)
+	fmt.Println("1~3 (transitive):", ds.Connected(1, 3)) // true
+	fmt.Println("1~4:", ds.Connected(1, 4))              // false
+	fmt.Println("sets:", ds.SetCount())                  // 4
+
+	ds.Union(3, 1)                                            // already connected: no-op
+	fmt.Println("sets after redundant union:", ds.SetCount()) // 4
+
+	ds.Union(4, 5)
+	ds.Union(5, 1)
+	fmt.Println("4~2:", ds.Connected(4, 2), "6~1:", ds.Connected(6, 1)) // true false
+	fmt.Println("sets:", ds.SetCount())                                 // 2
+}
@@ -0,0 +1,70 @@
+package main
+
+import "testing"
+
+func TestUnionIsTransitive(t *testing.T) {
+	ds := NewDisjointSet()
+	for i := 1; i <= 6; i++ {
+		ds.Find(i) // add 1..6 as singletons
+	}
+	if got := ds.SetCount(); got != 6 {
+		t.Fatalf("SetCount() = %d, want 6", got)
+	}
+
+	ds.Union(1, 2)
+	ds.Union(2, 3)
+	ds.Union(4, 5)
+	tests := []struct {
+		a, b int
+		want bool
+	}{
+		{1, 3, true}, // through 2
+		{3, 1, true},
+		{4, 5, true},
+		{1, 4, false},
+		{6, 1, false},
+		{6, 6, true},
+	}
+	for _, tt := range tests {
+		if got := ds.Connected(tt.a, tt.b); got != tt.want {
+			t.Errorf("Connected(%d, %d) = %v, want %v", tt.a, tt.b, got, tt.want)
+		}
+	}
+	if got := ds.SetCount(); got != 3 {
+		t.Errorf("SetCount() = %d, want 3", got)
+	}
+
+	ds.Union(5, 1) // joins {1, 2, 3} and {4, 5}
+	if !ds.Connected(4, 2) || ds.SetCount() != 2 {
+		t.Errorf("after Union(5, 1): Connected(4, 2) = %v, SetCount() = %d, want true, 2", ds.Connected(4, 2), ds.SetCount())
+	}
+}
+
+func TestUnionOfConnectedIsNoOp(t *testing.T) {
+	ds := NewDisjointSet()
+	ds.Union(1, 2)
+	ds.Union(2, 3)
+	root, count := ds.Find(1), ds.SetCount()
+
+	ds.Union(3, 1)
+	ds.Union(1, 1)
+	if got := ds.SetCount(); got != count {
+		t.Errorf("SetCount() after redundant unions = %d, want %d", got, count)
+	}
+	if got := ds.Find(3); got != root {
+		t.Errorf("Find(3) = %d, want the unchanged root %d", got, root)
+	}
+}
+
+func TestFindAddsNewElements(t *testing.T) {
+	ds := NewDisjointSet()
+	if got := ds.Find(42); got != 42 {
+		t.Errorf("Find(42) on a new element = %d, want 42", got)
+	}
+	if ds.Connected(7, 8) {
+		t.Error("Connected(7, 8) = true for two new elements")
+	}
+	if got := ds.SetCount(); got != 3 {
+		t.Errorf("SetCount() = %d, want 3", got)
+	}
+}
@@ -1,32 +1,85 @@
 package main
 
 import (
+	"container/heap"
+	"container/list"
+	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"math"
+	"sort"
+	"sync"
 )
+
+var (
+	ErrVertexNotFound = errors.New("vertex not found")
+	ErrNoPath         = errors.New("no path between vertices")
+	ErrNegativeWeight = errors.New("negative edge weight")
+	ErrCycle          = errors.New("graph contains a cycle")
+)
+
 // DirectedGraph represents a simple directed graph using an adjacency list
 type DirectedGraph struct {
 	adjacencyList map[int][]int
+	weights       map[edge]float64 // weights set by AddWeightedEdge
+
+	// AllowMultiEdges lets AddEdge add the same edge more than once. By default
+	// adding an edge that already exists does nothing.
+	AllowMultiEdges bool
+
+	version uint64 // bumped on every mutation so caches can tell they are stale
 }
+
+// edge identifies a directed edge from -> to
+type edge struct{ from, to int }
+
 // NewDirectedGraph initializes and returns a new DirectedGraph
 func NewDirectedGraph() *DirectedGraph {
 	return &DirectedGraph{
 		adjacencyList: make(map[int][]int),
+		weights:       make(map[edge]float64),
+	}
+}
+
+// AddWeightedEdge adds a directed edge from v1 to v2 with the given weight.
+// Edges added with AddEdge have weight 1.
+func (g *DirectedGraph) AddWeightedEdge(v1, v2 int, weight float64) {
+	g.AddEdge(v1, v2)
+	if g.weights == nil {
+		g.weights = make(map[edge]float64)
+	}
+	g.weights[edge{v1, v2}] = weight
+	g.version++
+}
+
+// Weight returns the weight of the edge from v1 to v2
+func (g *DirectedGraph) Weight(v1, v2 int) float64 {
+	if w, ok := g.weights[edge{v1, v2}]; ok {
+		return w
 	}
+	return 1
 }
 // AddVertex adds a new vertex to the directed graph
 func (g *DirectedGraph) AddVertex(vertex int) {
 	if _, exists := g.adjacencyList[vertex]; !exists {
 		g.adjacencyList[vertex] = []int{}
+		g.version++
 		return
 	}
 	fmt.Printf("Vertex %d already exists \n", vertex)
 }
 
-// AddEdge adds a directed edge from v1 to v2
+// AddEdge adds a directed edge from v1 to v2, unless it already exists and
+// AllowMultiEdges is false
 func (g *DirectedGraph) AddEdge(v1, v2 int) {
 	g.AddVertex(v1)
 	g.AddVertex(v2)
+	if !g.AllowMultiEdges && g.HasEdge(v1, v2) {
+		return
+	}
 	g.adjacencyList[v1] = append(g.adjacencyList[v1], v2)
+	g.version++
 
 }
 
@@ -40,6 +93,8 @@ func (g *DirectedGraph) PrintGraph() {
 
 func (g *DirectedGraph) RemoveEdge(v1, v2 int) {
 	g.adjacencyList[v1] = removeFromSlice(g.adjacencyList[v1], v2)
+	delete(g.weights, edge{v1, v2})
+	g.version++
 }
 func removeFromSlice(slice []int, value int) []int {
 	for i, v := range slice {
@@ -55,7 +110,14 @@ func (g *DirectedGraph) RemoveVertex(vertex int) {
 	delete(g.adjacencyList, vertex)
 	for v, neighbors := range g.adjacencyList {
 		g.adjacencyList[v] = removeFromSlice(neighbors, vertex)
+		delete(g.weights, edge{v, vertex})
+	}
+	for e := range g.weights {
+		if e.from == vertex {
+			delete(g.weights, e)
+		}
 	}
+	g.version++
 }
 
 
@@ -80,6 +142,45 @@ func (	g *DirectedGraph) BFS(start int) {
 	fmt.Println()
 }
 
+// BFSOrder returns the vertices reachable from start in breadth-first order,
+// or an empty slice if start isn't in the graph
+func (g *DirectedGraph) BFSOrder(start int) []int {
+	order, _ := g.BFSOrderCtx(context.Background(), start)
+	return order
+}
+
+// ctxCheckInterval is how many vertices a cancellable traversal visits
+// between checks of its context
+const ctxCheckInterval = 1024
+
+// BFSOrderCtx is BFSOrder that can be aborted. Every ctxCheckInterval vertices
+// it checks ctx, and if ctx is done it returns the order so far with ctx.Err().
+func (g *DirectedGraph) BFSOrderCtx(ctx context.Context, start int) ([]int, error) {
+	order := []int{}
+	if _, ok := g.adjacencyList[start]; !ok {
+		return order, nil
+	}
+	visited := map[int]bool{start: true}
+	queue := []int{start}
+	for len(queue) > 0 {
+		if len(order)%ctxCheckInterval == 0 {
+			if err := ctx.Err(); err != nil {
+				return order, err
+			}
+		}
+		current := queue[0]
+		queue = queue[1:]
+		order = append(order, current)
+		for _, neighbor := range g.adjacencyList[current] {
+			if !visited[neighbor] {
+				visited[neighbor] = true
+				queue = append(queue, neighbor)
+			}
+		}
+	}
+	return order, nil
+}
+
 func (g *DirectedGraph) DFS(start int) {
 	visited := make(map[int]bool)
 	stack := []int{start}
@@ -104,6 +205,87 @@ func (g *DirectedGraph) DFS(start int) {
 	fmt.Println()
 }
 
+// DFSOrderIterative returns the vertices reachable from start in the same
+// depth-first order as DFS_recursion, visiting neighbors in insertion order.
+// It keeps an explicit stack of (vertex, next neighbor) frames instead of
+// recursing, so very deep graphs can't overflow the goroutine stack.
+func (g *DirectedGraph) DFSOrderIterative(start int) []int {
+	order, _ := g.DFSOrderCtx(context.Background(), start)
+	return order
+}
+
+// DFSOrderCtx is DFSOrderIterative that can be aborted. Every
+// ctxCheckInterval vertices it checks ctx, and if ctx is done it returns the
+// order so far with ctx.Err().
+func (g *DirectedGraph) DFSOrderCtx(ctx context.Context, start int) ([]int, error) {
+	order := []int{}
+	if _, ok := g.adjacencyList[start]; !ok {
+		return order, nil
+	}
+	type frame struct {
+		vertex int
+		next   int // index of the next neighbor to look at
+	}
+	if err := ctx.Err(); err != nil {
+		return order, err
+	}
+	visited := map[int]bool{start: true}
+	order = append(order, start)
+	stack := []frame{{vertex: start}}
+	for len(stack) > 0 {
+		top := &stack[len(stack)-1]
+		neighbors := g.adjacencyList[top.vertex]
+		if top.next == len(neighbors) {
+			stack = stack[:len(stack)-1]
+			continue
+		}
+		neighbor := neighbors[top.next]
+		top.next++
+		if !visited[neighbor] {
+			if len(order)%ctxCheckInterval == 0 {
+				if err := ctx.Err(); err != nil {
+					return order, err
+				}
+			}
+			visited[neighbor] = true
+			order = append(order, neighbor)
+			stack = append(stack, frame{vertex: neighbor})
+		}
+	}
+	return order, nil
+}
+
+// CountPaths returns the number of distinct simple paths from start to end.
+// A vertex is never repeated on a path, so on a cyclic graph the cycles are
+// not unrolled: only simple paths are counted. This is a backtracking DFS and
+// takes exponential time in the worst case. start == end counts as one path,
+// and a missing vertex gives 0.
+func (g *DirectedGraph) CountPaths(start, end int) int {
+	if _, ok := g.adjacencyList[start]; !ok {
+		return 0
+	}
+	if _, ok := g.adjacencyList[end]; !ok {
+		return 0
+	}
+	onPath := make(map[int]bool)
+	var count func(v int) int
+	count = func(v int) int {
+		if v == end {
+			return 1
+		}
+		onPath[v] = true
+		total := 0
+		for _, neighbor := range g.adjacencyList[v] {
+			if !onPath[neighbor] {
+				total += count(neighbor)
+			}
+		}
+		onPath[v] = false // backtrack so other paths may pass through v
+		return total
+	}
+	return count(start)
+}
+
 func (g *DirectedGraph) dfsHelper(vertex int, visited map[int]bool) {
 	visited[vertex] = true
 	fmt.Printf("%d ", vertex)
@@ -124,6 +306,558 @@ func (g *DirectedGraph) DFS_recursion(start int) {
 
 
 }
+// OutDegree returns the number of edges leaving v, or -1 if v isn't in the graph
+func (g *DirectedGraph) OutDegree(v int) int {
+	neighbors, ok := g.adjacencyList[v]
+	if !ok {
+		return -1
+	}
+	return len(neighbors)
+}
+
+// InDegree returns the number of edges entering v, or -1 if v isn't in the graph
+func (g *DirectedGraph) InDegree(v int) int {
+	if _, ok := g.adjacencyList[v]; !ok {
+		return -1
+	}
+	degree := 0
+	for _, neighbors := range g.adjacencyList {
+		for _, neighbor := range neighbors {
+			if neighbor == v {
+				degree++
+			}
+		}
+	}
+	return degree
+}
+
+// graphJSON is the wire format for a DirectedGraph: the adjacency list keyed
+// by vertex, plus any explicit edge weights
+type graphJSON struct {
+	Adjacency map[int][]int `json:"adjacency"`
+	Weights   []weightJSON  `json:"weights,omitempty"`
+}
+
+type weightJSON struct {
+	From   int     `json:"from"`
+	To     int     `json:"to"`
+	Weight float64 `json:"weight"`
+}
+
+// MarshalJSON encodes the adjacency list, including vertices with no edges
+func (g *DirectedGraph) MarshalJSON() ([]byte, error) {
+	out := graphJSON{Adjacency: make(map[int][]int, len(g.adjacencyList))}
+	for v, neighbors := range g.adjacencyList {
+		out.Adjacency[v] = append([]int{}, neighbors...)
+	}
+	for _, v := range g.Vertices() {
+		for _, neighbor := range g.adjacencyList[v] {
+			if w, ok := g.weights[edge{v, neighbor}]; ok {
+				out.Weights = append(out.Weights, weightJSON{From: v, To: neighbor, Weight: w})
+			}
+		}
+	}
+	return json.Marshal(out)
+}
+
+// UnmarshalJSON replaces the graph with the one encoded by MarshalJSON.
+// Neighbors that aren't listed as vertices are added as vertices.
+func (g *DirectedGraph) UnmarshalJSON(data []byte) error {
+	var in graphJSON
+	if err := json.Unmarshal(data, &in); err != nil {
+		return err
+	}
+	// Reset the data but keep the caller's settings and version history
+	version, allowMulti := g.version, g.AllowMultiEdges
+	*g = *NewDirectedGraph()
+	g.version = version + 1
+	g.AllowMultiEdges = allowMulti
+	for v, neighbors := range in.Adjacency {
+		if _, exists := g.adjacencyList[v]; !exists {
+			g.adjacencyList[v] = []int{}
+		}
+		g.adjacencyList[v] = append(g.adjacencyList[v], neighbors...)
+		for _, neighbor := range neighbors {
+			if _, exists := g.adjacencyList[neighbor]; !exists {
+				g.adjacencyList[neighbor] = []int{}
+			}
+		}
+	}
+	for _, w := range in.Weights {
+		g.weights[edge{w.From, w.To}] = w.Weight
+	}
+	return nil
+}
+
+// HasEdge reports whether there is an edge from v1 to v2
+func (g *DirectedGraph) HasEdge(v1, v2 int) bool {
+	for _, neighbor := range g.adjacencyList[v1] {
+		if neighbor == v2 {
+			return true
+		}
+	}
+	return false
+}
+
+// Neighbors returns the vertices v has edges to, sorted. The slice is a copy,
+// so changing it doesn't change the graph.
+func (g *DirectedGraph) Neighbors(v int) []int {
+	neighbors := append([]int{}, g.adjacencyList[v]...)
+	sort.Ints(neighbors)
+	return neighbors
+}
+
+// Vertices returns the graph's vertices in ascending order
+func (g *DirectedGraph) Vertices() []int {
+	vertices := make([]int, 0, len(g.adjacencyList))
+	for v := range g.adjacencyList {
+		vertices = append(vertices, v)
+	}
+	sort.Ints(vertices)
+	return vertices
+}
+
+// Transpose returns a new graph with every edge reversed, keeping weights.
+// Every vertex is kept, including ones with no edges. Neighbors in the
+// transpose are listed in ascending order of the original source vertex.
+func (g *DirectedGraph) Transpose() *DirectedGraph {
+	t := NewDirectedGraph()
+	for _, v := range g.Vertices() {
+		if _, exists := t.adjacencyList[v]; !exists {
+			t.adjacencyList[v] = []int{}
+		}
+		for _, neighbor := range g.adjacencyList[v] {
+			t.adjacencyList[neighbor] = append(t.adjacencyList[neighbor], v)
+			if w, ok := g.weights[edge{v, neighbor}]; ok {
+				t.weights[edge{neighbor, v}] = w
+			}
+		}
+	}
+	return t
+}
+
+// StronglyConnectedComponents returns the strongly connected components using
+// Tarjan's algorithm: a single DFS that tracks, for each vertex, the lowest
+// discovery index reachable from it (its low-link). A vertex whose low-link is
+// its own index is the root of a component, which is then popped off the
+// stack. Each component is sorted, and components are ordered by their
+// smallest vertex.
+func (g *DirectedGraph) StronglyConnectedComponents() [][]int {
+	index := make(map[int]int)
+	lowLink := make(map[int]int)
+	onStack := make(map[int]bool)
+	var stack []int
+	var components [][]int
+	next := 0
+
+	var strongConnect func(v int)
+	strongConnect = func(v int) {
+		index[v] = next
+		lowLink[v] = next
+		next++
+		stack = append(stack, v)
+		onStack[v] = true
+
+		for _, neighbor := range g.adjacencyList[v] {
+			if _, visited := index[neighbor]; !visited {
+				strongConnect(neighbor)
+				lowLink[v] = min(lowLink[v], lowLink[neighbor])
+			} else if onStack[neighbor] {
+				lowLink[v] = min(lowLink[v], index[neighbor])
+			}
+		}
+
+		if lowLink[v] == index[v] {
+			var component []int
+			for {
+				top := stack[len(stack)-1]
+				stack = stack[:len(stack)-1]
+				onStack[top] = false
+				component = append(component, top)
+				if top == v {
+					break
+				}
+			}
+			sort.Ints(component)
+			components = append(components, component)
+		}
+	}
+
+	for _, v := range g.Vertices() {
+		if _, visited := index[v]; !visited {
+			strongConnect(v)
+		}
+	}
+	sort.Slice(components, func(i, j int) bool {
+		return components[i][0] < components[j][0]
+	})
+	return components
+}
+
+// TopologicalLevels groups the vertices of a dependency graph, where an edge
+// v1 -> v2 means v1 must run before v2, into levels that can run one after
+// another. Every vertex in a level depends only on vertices in earlier levels,
+// so the tasks within a level can run concurrently. Each level is built by
+// taking every vertex whose in-degree has dropped to zero (Kahn's algorithm,
+// a whole frontier at a time). Levels are sorted; ErrCycle is returned if the
+// graph has a cycle.
+func (g *DirectedGraph) TopologicalLevels() ([][]int, error) {
+	inDegree := make(map[int]int, len(g.adjacencyList))
+	for v, neighbors := range g.adjacencyList {
+		if _, seen := inDegree[v]; !seen {
+			inDegree[v] = 0
+		}
+		for _, neighbor := range neighbors {
+			inDegree[neighbor]++
+		}
+	}
+
+	var current []int
+	for _, v := range g.Vertices() {
+		if inDegree[v] == 0 {
+			current = append(current, v)
+		}
+	}
+
+	levels := [][]int{}
+	placed := 0
+	for len(current) > 0 {
+		levels = append(levels, current)
+		placed += len(current)
+		var next []int
+		for _, v := range current {
+			for _, neighbor := range g.adjacencyList[v] {
+				inDegree[neighbor]--
+				if inDegree[neighbor] == 0 {
+					next = append(next, neighbor)
+				}
+			}
+		}
+		sort.Ints(next)
+		current = next
+	}
+
+	if placed != len(g.adjacencyList) {
+		return nil, ErrCycle
+	}
+	return levels, nil
+}
+
+// ConcurrentDirectedGraph is a DirectedGraph that is safe for use by multiple
+// goroutines. Reads share an RLock; mutations take the exclusive Lock.
+type ConcurrentDirectedGraph struct {
+	mu    sync.RWMutex
+	graph *DirectedGraph
+}
+
+// NewConcurrentDirectedGraph returns an empty ConcurrentDirectedGraph
+func NewConcurrentDirectedGraph() *ConcurrentDirectedGraph {
+	return &ConcurrentDirectedGraph{graph: NewDirectedGraph()}
+}
+
+func (c *ConcurrentDirectedGraph) AddVertex(vertex int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.graph.AddVertex(vertex)
+}
+
+func (c *ConcurrentDirectedGraph) AddEdge(v1, v2 int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.graph.AddEdge(v1, v2)
+}
+
+func (c *ConcurrentDirectedGraph) AddWeightedEdge(v1, v2 int, weight float64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.graph.AddWeightedEdge(v1, v2, weight)
+}
+
+func (c *ConcurrentDirectedGraph) RemoveEdge(v1, v2 int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.graph.RemoveEdge(v1, v2)
+}
+
+func (c *ConcurrentDirectedGraph) RemoveVertex(vertex int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.graph.RemoveVertex(vertex)
+}
+
+func (c *ConcurrentDirectedGraph) BFSOrder(start int) []int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.graph.BFSOrder(start)
+}
+
+func (c *ConcurrentDirectedGraph) InDegree(v int) int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.graph.InDegree(v)
+}
+
+func (c *ConcurrentDirectedGraph) OutDegree(v int) int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.graph.OutDegree(v)
+}
+
+func (c *ConcurrentDirectedGraph) AStar(start, end int, heuristic func(node int) float64) ([]int, float64, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.graph.AStar(start, end, heuristic)
+}
+
+func (c *ConcurrentDirectedGraph) StronglyConnectedComponents() [][]int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.graph.StronglyConnectedComponents()
+}
+
+// Transpose returns a reversed copy as a plain DirectedGraph
+func (c *ConcurrentDirectedGraph) Transpose() *DirectedGraph {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.graph.Transpose()
+}
+
+func (c *ConcurrentDirectedGraph) MarshalJSON() ([]byte, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.graph.MarshalJSON()
+}
+
+// UnmarshalJSON replaces the whole graph, so it takes the exclusive Lock
+func (c *ConcurrentDirectedGraph) UnmarshalJSON(data []byte) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.graph.UnmarshalJSON(data)
+}
+
+func (c *ConcurrentDirectedGraph) Weight(v1, v2 int) float64 {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.graph.Weight(v1, v2)
+}
+
+func (c *ConcurrentDirectedGraph) HasEdge(v1, v2 int) bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.graph.HasEdge(v1, v2)
+}
+
+func (c *ConcurrentDirectedGraph) Neighbors(v int) []int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.graph.Neighbors(v)
+}
+
+func (c *ConcurrentDirectedGraph) Vertices() []int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.graph.Vertices()
+}
+
+// BFSOrderCtx holds the RLock for the whole traversal, so a long search
+// delays writers until it finishes or ctx is done
+func (c *ConcurrentDirectedGraph) BFSOrderCtx(ctx context.Context, start int) ([]int, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.graph.BFSOrderCtx(ctx, start)
+}
+
+func (c *ConcurrentDirectedGraph) DFSOrderIterative(start int) []int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.graph.DFSOrderIterative(start)
+}
+
+func (c *ConcurrentDirectedGraph) DFSOrderCtx(ctx context.Context, start int) ([]int, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.graph.DFSOrderCtx(ctx, start)
+}
+
+func (c *ConcurrentDirectedGraph) CountPaths(start, end int) int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.graph.CountPaths(start, end)
+}
+
+func (c *ConcurrentDirectedGraph) TopologicalLevels() ([][]int, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.graph.TopologicalLevels()
+}
+
+func (c *ConcurrentDirectedGraph) ShortestPath(start, end int) ([]int, float64, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.graph.ShortestPath(start, end)
+}
+
+// pathItem is a vertex waiting in the search frontier
+type pathItem struct {
+	vertex   int
+	dist     float64 // distance from start when the item was pushed
+	priority float64 // dist plus heuristic estimate
+}
+
+// pathQueue is a min-heap of pathItems for container/heap
+type pathQueue []pathItem
+
+func (p pathQueue) Len() int            { return len(p) }
+func (p pathQueue) Less(i, j int) bool  { return p[i].priority < p[j].priority }
+func (p pathQueue) Swap(i, j int)       { p[i], p[j] = p[j], p[i] }
+func (p *pathQueue) Push(x interface{}) { *p = append(*p, x.(pathItem)) }
+func (p *pathQueue) Pop() interface{} {
+	old := *p
+	n := len(old)
+	item := old[n-1]
+	*p = old[:n-1]
+	return item
+}
+
+// AStar finds the cheapest path from start to end, returning the vertices on
+// the path and its total weight. heuristic estimates the remaining cost from a
+// vertex to end; it must be admissible (never overestimate) or the returned
+// path may not be the shortest. An expanded vertex is reopened when a shorter
+// route to it turns up later, which is what makes an admissible heuristic
+// enough; a consistent one (h(u) <= w(u, v) + h(v) for every edge) never
+// needs that and expands each vertex at most once. A nil heuristic is treated
+// as zero, which makes this exactly Dijkstra's algorithm. Edge weights must
+// be non-negative.
+func (g *DirectedGraph) AStar(start, end int, heuristic func(node int) float64) ([]int, float64, error) {
+	path, cost, _, err := g.aStar(start, end, heuristic)
+	return path, cost, err
+}
+
+// ShortestPath finds the cheapest path from start to end using Dijkstra's algorithm
+func (g *DirectedGraph) ShortestPath(start, end int) ([]int, float64, error) {
+	return g.AStar(start, end, nil)
+}
+
+// aStar also reports how many vertices were expanded, to compare heuristics
+func (g *DirectedGraph) aStar(start, end int, heuristic func(node int) float64) ([]int, float64, int, error) {
+	if _, ok := g.adjacencyList[start]; !ok {
+		return nil, 0, 0, fmt.Errorf("start %d: %w", start, ErrVertexNotFound)
+	}
+	if _, ok := g.adjacencyList[end]; !ok {
+		return nil, 0, 0, fmt.Errorf("end %d: %w", end, ErrVertexNotFound)
+	}
+	if heuristic == nil {
+		heuristic = func(int) float64 { return 0 }
+	}
+
+	dist := map[int]float64{start: 0}
+	prev := make(map[int]int)
+	queue := &pathQueue{{vertex: start, priority: heuristic(start)}}
+	expanded := 0
+	reached := false
+
+	for queue.Len() > 0 {
+		item := heap.Pop(queue).(pathItem)
+		current := item.vertex
+		if item.dist > dist[current] {
+			continue // stale entry left behind by a cheaper push
+		}
+		expanded++
+		if current == end {
+			reached = true
+			break
+		}
+		for _, neighbor := range g.adjacencyList[current] {
+			w := g.Weight(current, neighbor)
+			if w < 0 {
+				return nil, 0, expanded, fmt.Errorf("%d -> %d: %w", current, neighbor, ErrNegativeWeight)
+			}
+			d := dist[current] + w
+			// A shorter route reopens neighbor even if it was already expanded
+			if old, seen := dist[neighbor]; !seen || d < old {
+				dist[neighbor] = d
+				prev[neighbor] = current
+				heap.Push(queue, pathItem{vertex: neighbor, dist: d, priority: d + heuristic(neighbor)})
+			}
+		}
+	}
+
+	if !reached {
+		return nil, math.Inf(1), expanded, fmt.Errorf("%d -> %d: %w", start, end, ErrNoPath)
+	}
+	path := []int{end}
+	for v := end; v != start; {
+		v = prev[v]
+		path = append([]int{v}, path...)
+	}
+	return path, dist[end], expanded, nil
+}
+
+// PathCache memoizes ShortestPath results for a graph in a bounded LRU.
+// Every mutation of the graph bumps its version, and the cache drops all
+// entries the first time it sees a new version, so it never serves a path
+// from before the graph changed. It is not safe for concurrent use.
+type PathCache struct {
+	graph    *DirectedGraph
+	capacity int
+	version  uint64 // graph version the cached entries belong to
+	items    map[edge]*list.Element
+	order    *list.List // front = most recently used
+
+	Hits, Misses int
+}
+
+type pathCacheEntry struct {
+	key  edge
+	path []int
+	cost float64
+	err  error
+}
+
+// NewPathCache creates a cache of at most capacity (start, end) results
+func NewPathCache(graph *DirectedGraph, capacity int) *PathCache {
+	return &PathCache{
+		graph:    graph,
+		capacity: capacity,
+		version:  graph.version,
+		items:    make(map[edge]*list.Element),
+		order:    list.New(),
+	}
+}
+
+// ShortestPath returns graph.ShortestPath(start, end), from the cache when
+// possible. Errors are cached too, since they only change with the graph.
+// The returned path is a copy the caller may modify.
+func (c *PathCache) ShortestPath(start, end int) ([]int, float64, error) {
+	if c.version != c.graph.version {
+		clear(c.items)
+		c.order.Init()
+		c.version = c.graph.version
+	}
+
+	key := edge{start, end}
+	if elem, ok := c.items[key]; ok {
+		c.Hits++
+		c.order.MoveToFront(elem)
+		entry := elem.Value.(*pathCacheEntry)
+		return append([]int(nil), entry.path...), entry.cost, entry.err
+	}
+
+	c.Misses++
+	path, cost, err := c.graph.ShortestPath(start, end)
+	if c.capacity <= 0 {
+		return path, cost, err
+	}
+	if c.order.Len() >= c.capacity {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.items, oldest.Value.(*pathCacheEntry).key)
+	}
+	entry := &pathCacheEntry{key: key, path: append([]int(nil), path...), cost: cost, err: err}
+	c.items[key] = c.order.PushFront(entry)
+	return path, cost, err
+}
+
 func main() {
 	graph := NewDirectedGraph()
 	graph.AddEdge(1, 2)
@@ -136,4 +870,160 @@ func main() {
 	graph.BFS(1)
 		graph.BFS(5)
 		graph.DFS_recursion(1)
+
+	// A* on a 10x10 grid; vertex id = row*10 + col, moving right or down costs 1
+	const size = 10
+	grid := NewDirectedGraph()
+	for r := 0; r < size; r++ {
+		for c := 0; c < size; c++ {
+			if c+1 < size {
+				grid.AddWeightedEdge(r*size+c, r*size+c+1, 1)
+			}
+			if r+1 < size {
+				grid.AddWeightedEdge(r*size+c, (r+1)*size+c, 1)
+			}
+		}
+	}
+	target := size*size - 1
+	manhattan := func(node int) float64 {
+		return math.Abs(float64(node/size-target/size)) + math.Abs(float64(node%size-target%size))
+	}
+	_, cost, dijkstraExpanded, _ := grid.aStar(0, target, nil)
+	path, aStarCost, aStarExpanded, _ := grid.aStar(0, target, manhattan)
+	fmt.Printf("Dijkstra: cost=%.0f expanded=%d\n", cost, dijkstraExpanded)
+	fmt.Printf("A*:       cost=%.0f expanded=%d path=%v\n", aStarCost, aStarExpanded, path)
+	if _, _, err := grid.AStar(target, 0, manhattan); errors.Is(err, ErrNoPath) {
+		fmt.Println("A* reverse:", err)
+	}
+
+	// Transpose of the DAG built above: 1->2, 1->3, 2->4, 3->4, 4->5
+	transposed := graph.Transpose()
+	want := map[int][]int{1: {}, 2: {1}, 3: {1}, 4: {2, 3}, 5: {4}}
+	for _, v := range transposed.Vertices() {
+		fmt.Printf("transpose %d: %v (want %v)\n", v, transposed.adjacencyList[v], want[v])
+	}
+
+	// The DAG has no cycles, so every vertex is its own component
+	fmt.Println("SCC (DAG):", graph.StronglyConnectedComponents())
+
+	cycle := NewDirectedGraph()
+	cycle.AddEdge(1, 2)
+	cycle.AddEdge(2, 3)
+	cycle.AddEdge(3, 4)
+	cycle.AddEdge(4, 1)
+	fmt.Println("SCC (cycle):", cycle.StronglyConnectedComponents()) // [[1 2 3 4]]
+
+	mixed := NewDirectedGraph()
+	mixed.AddEdge(1, 2)
+	mixed.AddEdge(2, 1)
+	mixed.AddEdge(2, 3)
+	mixed.AddEdge(3, 4)
+	mixed.AddEdge(4, 5)
+	mixed.AddEdge(5, 3)
+	mixed.AddVertex(6)
+	fmt.Println("SCC (mixed):", mixed.StronglyConnectedComponents()) // [[1 2] [3 4 5] [6]]
+
+	// Degrees: 4 has two incoming edges, 6 has none, 9 doesn't exist
+	fmt.Println("in(4):", graph.InDegree(4), "out(1):", graph.OutDegree(1)) // 2 2
+	fmt.Println("in(6):", mixed.InDegree(6), "out(6):", mixed.OutDegree(6)) // 0 0
+	fmt.Println("in(9):", graph.InDegree(9), "out(9):", graph.OutDegree(9)) // -1 -1
+
+	// JSON round trip keeps the isolated vertex 6 and the weights
+	mixed.AddWeightedEdge(1, 6, 2.5)
+	data, err := json.Marshal(mixed)
+	if err != nil {
+		fmt.Println("marshal:", err)
+		return
+	}
+	fmt.Println("JSON:", string(data))
+	var restored DirectedGraph
+	if err := json.Unmarshal(data, &restored); err != nil {
+		fmt.Println("unmarshal:", err)
+		return
+	}
+	fmt.Println("restored SCC:", restored.StronglyConnectedComponents(), "weight(1,6):", restored.Weight(1, 6))
+
+	graph.DFS_recursion(1)
+	fmt.Println("DFS (iterative):", graph.DFSOrderIterative(1))
+
+	// A 100k-deep chain 0 -> 1 -> ... -> 99999, built directly to skip the
+	// "already exists" output from AddEdge
+	const depth = 100000
+	chain := NewDirectedGraph()
+	for i := 0; i < depth; i++ {
+		chain.adjacencyList[i] = []int{i + 1}
+	}
+	chain.adjacencyList[depth] = []int{}
+	deep := chain.DFSOrderIterative(0)
+	fmt.Println("deep chain visited:", len(deep), "last:", deep[len(deep)-1])
+
+	// Cancelled traversals stop at the next check and return what they have
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	partialBFS, err := chain.BFSOrderCtx(ctx, 0)
+	fmt.Println("cancelled BFS visited:", len(partialBFS), "err:", err)
+	partialDFS, err := chain.DFSOrderCtx(ctx, 0)
+	fmt.Println("cancelled DFS visited:", len(partialDFS), "err:", err)
+	fullBFS, err := chain.BFSOrderCtx(context.Background(), 0)
+	fmt.Println("uncancelled BFS visited:", len(fullBFS), "err:", err)
+
+	// The DAG is a diamond 1 -> {2, 3} -> 4 followed by 4 -> 5
+	fmt.Println("paths 1->4:", graph.CountPaths(1, 4)) // 2
+	graph.AddEdge(2, 6)                                // a branch that dead-ends
+	fmt.Println("paths 1->5:", graph.CountPaths(1, 5)) // 2
+	fmt.Println("paths 1->6:", graph.CountPaths(1, 6)) // 1
+	fmt.Println("paths 5->1:", graph.CountPaths(5, 1)) // 0
+	// In the cycle 1 -> 2 -> 3 -> 4 -> 1 there is exactly one simple path 1 -> 3
+	fmt.Println("paths 1->3 (cycle):", cycle.CountPaths(1, 3))
+
+	neighbors := graph.Neighbors(1)
+	neighbors[0] = 99 // a copy: the graph is unchanged
+	fmt.Println("vertices:", graph.Vertices(), "neighbors(1):", graph.Neighbors(1))
+	fmt.Println("has 1->2:", graph.HasEdge(1, 2), "has 2->1:", graph.HasEdge(2, 1))
+
+	simple := NewDirectedGraph()
+	simple.AddEdge(1, 2)
+	simple.AddEdge(1, 2)
+	fmt.Println("duplicate edge skipped:", len(simple.Neighbors(1)) == 1, "out(1):", simple.OutDegree(1))
+	multi := NewDirectedGraph()
+	multi.AllowMultiEdges = true
+	multi.AddEdge(1, 2)
+	multi.AddEdge(1, 2)
+	fmt.Println("multigraph neighbors(1):", multi.Neighbors(1), "paths 1->2:", multi.CountPaths(1, 2))
+	multiData, _ := json.Marshal(multi)
+	if err := json.Unmarshal(multiData, multi); err != nil {
+		fmt.Println("unmarshal:", err)
+	}
+	multi.AddEdge(1, 2)
+	fmt.Println("AllowMultiEdges kept after decode:", multi.AllowMultiEdges, "neighbors(1):", multi.Neighbors(1))
+
+	// Diamond build: 1 before 2 and 3, both before 4
+	build := NewDirectedGraph()
+	build.AddEdge(1, 2)
+	build.AddEdge(1, 3)
+	build.AddEdge(2, 4)
+	build.AddEdge(3, 4)
+	levels, err := build.TopologicalLevels()
+	fmt.Println("diamond levels:", levels, err) // [[1] [2 3] [4]] <nil>
+	_, err = cycle.TopologicalLevels()
+	fmt.Println("cycle levels error:", err)
+
+	roads := NewDirectedGraph()
+	roads.AddWeightedEdge(1, 2, 4)
+	roads.AddWeightedEdge(2, 3, 4)
+	roads.AddWeightedEdge(1, 3, 10)
+	cache := NewPathCache(roads, 2)
+	route, routeCost, _ := cache.ShortestPath(1, 3)
+	fmt.Println("cached path 1->3:", route, routeCost, "hits:", cache.Hits, "misses:", cache.Misses) // 0 1
+	route, routeCost, _ = cache.ShortestPath(1, 3)
+	fmt.Println("cached path 1->3:", route, routeCost, "hits:", cache.Hits, "misses:", cache.Misses) // 1 1
+	// Mutating the graph invalidates the cache
+	roads.RemoveEdge(2, 3)
+	route, routeCost, _ = cache.ShortestPath(1, 3)
+	fmt.Println("after RemoveEdge 1->3:", route, routeCost, "hits:", cache.Hits, "misses:", cache.Misses) // 1 2
+	// With capacity 2, these two queries evict 1->3
+	cache.ShortestPath(1, 2)
+	cache.ShortestPath(2, 1)
+	cache.ShortestPath(1, 3)
+	fmt.Println("after eviction hits:", cache.Hits, "misses:", cache.Misses) // 1 5
 }
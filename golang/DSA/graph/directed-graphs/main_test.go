@@ -0,0 +1,553 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math"
+	"reflect"
+	"sync"
+	"testing"
+)
+
+// newGrid builds a size x size grid where vertex row*size+col has edges of
+// weight 1 to its right and lower neighbours
+func newGrid(size int) *DirectedGraph {
+	g := NewDirectedGraph()
+	for r := 0; r < size; r++ {
+		for c := 0; c < size; c++ {
+			if c+1 < size {
+				g.AddWeightedEdge(r*size+c, r*size+c+1, 1)
+			}
+			if r+1 < size {
+				g.AddWeightedEdge(r*size+c, (r+1)*size+c, 1)
+			}
+		}
+	}
+	return g
+}
+
+func TestAStarGridExpandsFewerThanDijkstra(t *testing.T) {
+	const size = 10
+	grid := newGrid(size)
+	target := size*size - 1
+	manhattan := func(node int) float64 {
+		return math.Abs(float64(node/size-target/size)) + math.Abs(float64(node%size-target%size))
+	}
+
+	_, dijkstraCost, dijkstraExpanded, err := grid.aStar(0, target, nil)
+	if err != nil {
+		t.Fatalf("Dijkstra: unexpected error %v", err)
+	}
+	path, aStarCost, aStarExpanded, err := grid.aStar(0, target, manhattan)
+	if err != nil {
+		t.Fatalf("A*: unexpected error %v", err)
+	}
+
+	if dijkstraCost != 18 || aStarCost != 18 {
+		t.Errorf("costs = %v (Dijkstra), %v (A*), want 18 for both", dijkstraCost, aStarCost)
+	}
+	if len(path) != 19 || path[0] != 0 || path[len(path)-1] != target {
+		t.Errorf("A* path = %v, want 19 vertices from 0 to %d", path, target)
+	}
+	if aStarExpanded >= dijkstraExpanded {
+		t.Errorf("A* expanded %d vertices, Dijkstra %d; want A* to expand fewer", aStarExpanded, dijkstraExpanded)
+	}
+	if _, _, err := grid.AStar(target, 0, manhattan); !errors.Is(err, ErrNoPath) {
+		t.Errorf("AStar(%d, 0) error = %v, want ErrNoPath", target, err)
+	}
+}
+
+func TestAStarZeroHeuristicMatchesShortestPath(t *testing.T) {
+	grid := newGrid(5)
+	zero := func(int) float64 { return 0 }
+
+	wantPath, wantCost, err := grid.ShortestPath(0, 24)
+	if err != nil {
+		t.Fatalf("ShortestPath: unexpected error %v", err)
+	}
+	path, cost, err := grid.AStar(0, 24, zero)
+	if err != nil {
+		t.Fatalf("AStar: unexpected error %v", err)
+	}
+	if !reflect.DeepEqual(path, wantPath) || cost != wantCost {
+		t.Errorf("AStar with zero heuristic = %v, %v, want %v, %v", path, cost, wantPath, wantCost)
+	}
+}
+
+// An admissible but inconsistent heuristic makes A* expand vertex 1 through
+// the expensive edge first; the cheaper route via 2 is only found afterwards
+// and must reopen 1 for the optimal path to come out.
+func TestAStarReopensWithInconsistentHeuristic(t *testing.T) {
+	g := NewDirectedGraph()
+	g.AddWeightedEdge(0, 1, 4)
+	g.AddWeightedEdge(0, 2, 1)
+	g.AddWeightedEdge(2, 1, 1)
+	g.AddWeightedEdge(1, 3, 4)
+	// True remaining costs are 6, 4, 5 and 0; h(2) = 5 is exact but
+	// overshoots w(2, 1) + h(1) = 1
+	heuristic := func(node int) float64 {
+		if node == 2 {
+			return 5
+		}
+		return 0
+	}
+
+	path, cost, err := g.AStar(0, 3, heuristic)
+	if err != nil {
+		t.Fatalf("AStar: unexpected error %v", err)
+	}
+	if want := []int{0, 2, 1, 3}; !reflect.DeepEqual(path, want) || cost != 6 {
+		t.Errorf("AStar = %v, %v, want %v, 6", path, cost, want)
+	}
+}
+
+// newDAG builds the diamond 1 -> {2, 3} -> 4 followed by 4 -> 5
+func newDAG() *DirectedGraph {
+	g := NewDirectedGraph()
+	g.AddEdge(1, 2)
+	g.AddEdge(1, 3)
+	g.AddEdge(2, 4)
+	g.AddEdge(3, 4)
+	g.AddEdge(4, 5)
+	return g
+}
+
+func TestTranspose(t *testing.T) {
+	// The newDAG diamond, with a weighted last edge
+	g := NewDirectedGraph()
+	g.AddEdge(1, 2)
+	g.AddEdge(1, 3)
+	g.AddEdge(2, 4)
+	g.AddEdge(3, 4)
+	g.AddWeightedEdge(4, 5, 2.5)
+	transposed := g.Transpose()
+
+	want := map[int][]int{1: {}, 2: {1}, 3: {1}, 4: {2, 3}, 5: {4}}
+	if !reflect.DeepEqual(transposed.adjacencyList, want) {
+		t.Errorf("Transpose() adjacency = %v, want %v", transposed.adjacencyList, want)
+	}
+	if w := transposed.Weight(5, 4); w != 2.5 {
+		t.Errorf("transposed Weight(5, 4) = %v, want 2.5", w)
+	}
+	original := map[int][]int{1: {2, 3}, 2: {4}, 3: {4}, 4: {5}, 5: {}}
+	if !reflect.DeepEqual(g.adjacencyList, original) {
+		t.Errorf("Transpose() changed the original to %v, want %v", g.adjacencyList, original)
+	}
+}
+
+func TestStronglyConnectedComponents(t *testing.T) {
+	cycle := NewDirectedGraph()
+	cycle.AddEdge(1, 2)
+	cycle.AddEdge(2, 3)
+	cycle.AddEdge(3, 4)
+	cycle.AddEdge(4, 1)
+
+	mixed := NewDirectedGraph()
+	mixed.AddEdge(1, 2)
+	mixed.AddEdge(2, 1)
+	mixed.AddEdge(2, 3)
+	mixed.AddEdge(3, 4)
+	mixed.AddEdge(4, 5)
+	mixed.AddEdge(5, 3)
+	mixed.AddVertex(6)
+
+	tests := []struct {
+		name  string
+		graph *DirectedGraph
+		want  [][]int
+	}{
+		{name: "DAG", graph: newDAG(), want: [][]int{{1}, {2}, {3}, {4}, {5}}},
+		{name: "one big cycle", graph: cycle, want: [][]int{{1, 2, 3, 4}}},
+		{name: "mixed", graph: mixed, want: [][]int{{1, 2}, {3, 4, 5}, {6}}},
+		{name: "empty", graph: NewDirectedGraph(), want: nil},
+	}
+	for _, tt := range tests {
+		if got := tt.graph.StronglyConnectedComponents(); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: StronglyConnectedComponents() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestDegrees(t *testing.T) {
+	g := newDAG()
+	g.AddVertex(6)
+
+	tests := []struct {
+		vertex  int
+		wantIn  int
+		wantOut int
+	}{
+		{vertex: 1, wantIn: 0, wantOut: 2},
+		{vertex: 4, wantIn: 2, wantOut: 1},
+		{vertex: 5, wantIn: 1, wantOut: 0},
+		{vertex: 6, wantIn: 0, wantOut: 0},
+		{vertex: 9, wantIn: -1, wantOut: -1},
+	}
+	for _, tt := range tests {
+		if got := g.InDegree(tt.vertex); got != tt.wantIn {
+			t.Errorf("InDegree(%d) = %d, want %d", tt.vertex, got, tt.wantIn)
+		}
+		if got := g.OutDegree(tt.vertex); got != tt.wantOut {
+			t.Errorf("OutDegree(%d) = %d, want %d", tt.vertex, got, tt.wantOut)
+		}
+	}
+}
+
+func TestJSONRoundTrip(t *testing.T) {
+	g := newDAG()
+	g.AddVertex(6)
+	g.AddWeightedEdge(1, 3, 2.5)
+
+	data, err := json.Marshal(g)
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+	var restored DirectedGraph
+	if err := json.Unmarshal(data, &restored); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+
+	if !reflect.DeepEqual(restored.adjacencyList, g.adjacencyList) {
+		t.Errorf("restored adjacency = %v, want %v", restored.adjacencyList, g.adjacencyList)
+	}
+	if !reflect.DeepEqual(restored.weights, g.weights) {
+		t.Errorf("restored weights = %v, want %v", restored.weights, g.weights)
+	}
+}
+
+// Run with -race: writers and readers share the graph with no other locking
+func TestConcurrentDirectedGraph(t *testing.T) {
+	const writers, edgesPerWriter = 4, 50
+	shared := NewConcurrentDirectedGraph()
+	shared.AddVertex(0)
+
+	var wg sync.WaitGroup
+	for w := 0; w < writers; w++ {
+		wg.Add(2)
+		go func(w int) {
+			defer wg.Done()
+			for i := 0; i < edgesPerWriter; i++ {
+				shared.AddEdge(w*edgesPerWriter+i, w*edgesPerWriter+i+1)
+			}
+		}(w)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < edgesPerWriter; i++ {
+				shared.BFSOrder(0)
+				shared.DFSOrderIterative(0)
+				shared.Neighbors(i)
+				shared.HasEdge(i, i+1)
+				shared.Vertices()
+				shared.TopologicalLevels()
+				shared.ShortestPath(0, i)
+				shared.CountPaths(0, i)
+			}
+		}()
+	}
+	wg.Wait()
+
+	// The writers' chains join into 0 -> 1 -> ... -> 200
+	const last = writers * edgesPerWriter
+	if got := len(shared.BFSOrder(0)); got != last+1 {
+		t.Errorf("BFSOrder(0) reached %d vertices, want %d", got, last+1)
+	}
+	if got := len(shared.Vertices()); got != last+1 {
+		t.Errorf("Vertices() has %d entries, want %d", got, last+1)
+	}
+	for v := 0; v < last; v++ {
+		if !shared.HasEdge(v, v+1) {
+			t.Fatalf("edge %d -> %d missing", v, v+1)
+		}
+	}
+}
+
+func TestDFSOrderIterativeMatchesRecursiveOrder(t *testing.T) {
+	// DFS_recursion visits 1 2 4 5 3 on this graph
+	if got, want := newDAG().DFSOrderIterative(1), []int{1, 2, 4, 5, 3}; !reflect.DeepEqual(got, want) {
+		t.Errorf("DFSOrderIterative(1) = %v, want %v", got, want)
+	}
+	if got := newDAG().DFSOrderIterative(9); len(got) != 0 {
+		t.Errorf("DFSOrderIterative(9) = %v, want empty", got)
+	}
+}
+
+// newChain builds 0 -> 1 -> ... -> depth directly, skipping AddEdge's output
+func newChain(depth int) *DirectedGraph {
+	g := NewDirectedGraph()
+	for i := 0; i < depth; i++ {
+		g.adjacencyList[i] = []int{i + 1}
+	}
+	g.adjacencyList[depth] = []int{}
+	return g
+}
+
+func TestDFSOrderIterativeDeepChain(t *testing.T) {
+	const depth = 100000
+	order := newChain(depth).DFSOrderIterative(0)
+	if len(order) != depth+1 {
+		t.Fatalf("visited %d vertices, want %d", len(order), depth+1)
+	}
+	for i, v := range order {
+		if v != i {
+			t.Fatalf("order[%d] = %d, want %d", i, v, i)
+		}
+	}
+}
+
+func TestCountPaths(t *testing.T) {
+	dag := newDAG()
+	dag.AddEdge(2, 6) // a branch that dead-ends
+
+	cycle := NewDirectedGraph()
+	cycle.AddEdge(1, 2)
+	cycle.AddEdge(2, 3)
+	cycle.AddEdge(3, 4)
+	cycle.AddEdge(4, 1)
+
+	tests := []struct {
+		name       string
+		graph      *DirectedGraph
+		start, end int
+		want       int
+	}{
+		{name: "diamond", graph: dag, start: 1, end: 4, want: 2},
+		{name: "past the diamond", graph: dag, start: 1, end: 5, want: 2},
+		{name: "dead end", graph: dag, start: 1, end: 6, want: 1},
+		{name: "from the dead end", graph: dag, start: 6, end: 5, want: 0},
+		{name: "against the edges", graph: dag, start: 5, end: 1, want: 0},
+		{name: "start is end", graph: dag, start: 3, end: 3, want: 1},
+		{name: "missing vertex", graph: dag, start: 1, end: 9, want: 0},
+		{name: "cycle", graph: cycle, start: 1, end: 3, want: 1},
+	}
+	for _, tt := range tests {
+		if got := tt.graph.CountPaths(tt.start, tt.end); got != tt.want {
+			t.Errorf("%s: CountPaths(%d, %d) = %d, want %d", tt.name, tt.start, tt.end, got, tt.want)
+		}
+	}
+}
+
+func TestAccessors(t *testing.T) {
+	g := newDAG()
+	g.AddVertex(6)
+
+	if got, want := g.Vertices(), []int{1, 2, 3, 4, 5, 6}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Vertices() = %v, want %v", got, want)
+	}
+	if !g.HasEdge(1, 2) || g.HasEdge(2, 1) || g.HasEdge(1, 9) {
+		t.Error("HasEdge does not follow edge direction")
+	}
+
+	g.AddEdge(1, 0) // added last but listed first
+	neighbors := g.Neighbors(1)
+	if want := []int{0, 2, 3}; !reflect.DeepEqual(neighbors, want) {
+		t.Errorf("Neighbors(1) = %v, want %v", neighbors, want)
+	}
+	neighbors[0] = 99 // a copy: the graph is unchanged
+	if !g.HasEdge(1, 0) || g.HasEdge(1, 99) {
+		t.Error("changing the slice from Neighbors changed the graph")
+	}
+	if got := g.Neighbors(9); len(got) != 0 {
+		t.Errorf("Neighbors(9) = %v, want empty", got)
+	}
+}
+
+func TestAddEdgeSkipsDuplicates(t *testing.T) {
+	g := NewDirectedGraph()
+	g.AddEdge(1, 2)
+	g.AddEdge(1, 2)
+	if got := g.Neighbors(1); len(got) != 1 {
+		t.Errorf("Neighbors(1) = %v, want a single edge", got)
+	}
+	if got := g.OutDegree(1); got != 1 {
+		t.Errorf("OutDegree(1) = %d, want 1", got)
+	}
+
+	multi := NewDirectedGraph()
+	multi.AllowMultiEdges = true
+	multi.AddEdge(1, 2)
+	multi.AddEdge(1, 2)
+	if got, want := multi.Neighbors(1), []int{2, 2}; !reflect.DeepEqual(got, want) {
+		t.Errorf("multigraph Neighbors(1) = %v, want %v", got, want)
+	}
+	if got := multi.CountPaths(1, 2); got != 2 {
+		t.Errorf("multigraph CountPaths(1, 2) = %d, want 2", got)
+	}
+
+	// Decoding keeps the caller's AllowMultiEdges setting
+	data, err := json.Marshal(multi)
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+	if err := json.Unmarshal(data, multi); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	multi.AddEdge(1, 2)
+	if got := len(multi.Neighbors(1)); !multi.AllowMultiEdges || got != 3 {
+		t.Errorf("after decoding: AllowMultiEdges = %v, %d edges 1 -> 2, want true, 3", multi.AllowMultiEdges, got)
+	}
+}
+
+// newGraph builds a graph from edges given as from, to pairs
+func newGraph(edges ...[2]int) *DirectedGraph {
+	g := NewDirectedGraph()
+	for _, e := range edges {
+		g.AddEdge(e[0], e[1])
+	}
+	return g
+}
+
+func TestTopologicalLevels(t *testing.T) {
+	tests := []struct {
+		name  string
+		graph *DirectedGraph
+		want  [][]int
+	}{
+		// 1 before 2 and 3, both before 4: 2 and 3 can run together
+		{name: "diamond", graph: newGraph([2]int{1, 2}, [2]int{1, 3}, [2]int{2, 4}, [2]int{3, 4}), want: [][]int{{1}, {2, 3}, {4}}},
+		// 4 waits for its slowest dependency, 3, even though 1 -> 4 is direct
+		{name: "uneven", graph: newGraph([2]int{1, 2}, [2]int{2, 3}, [2]int{3, 4}, [2]int{1, 4}), want: [][]int{{1}, {2}, {3}, {4}}},
+		{name: "independent chains", graph: newGraph([2]int{1, 2}, [2]int{5, 6}, [2]int{6, 7}), want: [][]int{{1, 5}, {2, 6}, {7}}},
+		{name: "empty", graph: NewDirectedGraph(), want: [][]int{}},
+	}
+	for _, tt := range tests {
+		got, err := tt.graph.TopologicalLevels()
+		if err != nil || !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: TopologicalLevels() = %v, %v, want %v, nil", tt.name, got, err, tt.want)
+		}
+	}
+}
+
+func TestTopologicalLevelsCycle(t *testing.T) {
+	// 1 -> 2 -> 3 -> 2: vertex 1 is placed but the cycle never frees up
+	g := newGraph([2]int{1, 2}, [2]int{2, 3}, [2]int{3, 2})
+	if levels, err := g.TopologicalLevels(); !errors.Is(err, ErrCycle) || levels != nil {
+		t.Errorf("TopologicalLevels() = %v, %v, want nil, ErrCycle", levels, err)
+	}
+}
+
+// cancelAfterChecks is a context that reports itself cancelled once Err has
+// been called checks times, so a test can cancel a traversal part way through
+type cancelAfterChecks struct {
+	context.Context
+	checks int
+}
+
+func (c *cancelAfterChecks) Err() error {
+	if c.checks == 0 {
+		return context.Canceled
+	}
+	c.checks--
+	return nil
+}
+
+func TestTraversalCtxCancellation(t *testing.T) {
+	const depth = 10 * ctxCheckInterval
+	chain := newChain(depth)
+	traversals := []struct {
+		name string
+		run  func(context.Context, int) ([]int, error)
+	}{
+		{name: "BFSOrderCtx", run: chain.BFSOrderCtx},
+		{name: "DFSOrderCtx", run: chain.DFSOrderCtx},
+	}
+	for _, tr := range traversals {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		order, err := tr.run(ctx, 0)
+		if !errors.Is(err, context.Canceled) || len(order) != 0 {
+			t.Errorf("%s with a cancelled ctx = %d vertices, %v, want 0, context.Canceled", tr.name, len(order), err)
+		}
+
+		// The checks at 0, 1, and 2 intervals pass and the fourth finds ctx
+		// done, so the traversal stops with three intervals' worth of vertices
+		order, err = tr.run(&cancelAfterChecks{Context: context.Background(), checks: 3}, 0)
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("%s cancelled part way: err = %v, want context.Canceled", tr.name, err)
+		}
+		if len(order) != 3*ctxCheckInterval {
+			t.Errorf("%s cancelled part way visited %d vertices, want %d", tr.name, len(order), 3*ctxCheckInterval)
+		}
+		for i, v := range order {
+			if v != i {
+				t.Fatalf("%s partial order[%d] = %d, want %d", tr.name, i, v, i)
+			}
+		}
+
+		order, err = tr.run(context.Background(), 0)
+		if err != nil || len(order) != depth+1 {
+			t.Errorf("%s uncancelled = %d vertices, %v, want %d, nil", tr.name, len(order), err, depth+1)
+		}
+	}
+}
+
+// newRoads builds 1 -> 2 -> 3 at cost 4 each plus a direct 1 -> 3 at cost 10
+func newRoads() *DirectedGraph {
+	g := NewDirectedGraph()
+	g.AddWeightedEdge(1, 2, 4)
+	g.AddWeightedEdge(2, 3, 4)
+	g.AddWeightedEdge(1, 3, 10)
+	return g
+}
+
+func TestPathCacheHitsAndInvalidation(t *testing.T) {
+	roads := newRoads()
+	cache := NewPathCache(roads, 2)
+
+	// query runs one lookup of 1 -> 3 and checks the result and counters
+	query := func(step string, wantPath []int, wantCost float64, wantHits, wantMisses int) {
+		t.Helper()
+		path, cost, err := cache.ShortestPath(1, 3)
+		if err != nil || !reflect.DeepEqual(path, wantPath) || cost != wantCost {
+			t.Errorf("%s: ShortestPath(1, 3) = %v, %v, %v, want %v, %v, nil", step, path, cost, err, wantPath, wantCost)
+		}
+		if cache.Hits != wantHits || cache.Misses != wantMisses {
+			t.Errorf("%s: hits, misses = %d, %d, want %d, %d", step, cache.Hits, cache.Misses, wantHits, wantMisses)
+		}
+	}
+
+	query("first query", []int{1, 2, 3}, 8, 0, 1)
+	query("repeat query", []int{1, 2, 3}, 8, 1, 1)
+
+	// A caller changing the returned path must not change the cached one
+	path, _, _ := cache.ShortestPath(1, 3)
+	path[0] = 99
+	query("after modifying a result", []int{1, 2, 3}, 8, 3, 1)
+
+	roads.RemoveEdge(2, 3)
+	query("after RemoveEdge", []int{1, 3}, 10, 3, 2)
+	roads.AddWeightedEdge(1, 4, 1)
+	query("after AddWeightedEdge", []int{1, 3}, 10, 3, 3)
+}
+
+func TestPathCacheEvictsLeastRecentlyUsed(t *testing.T) {
+	cache := NewPathCache(newRoads(), 2)
+	cache.ShortestPath(1, 3)
+	cache.ShortestPath(1, 2)
+	cache.ShortestPath(1, 3) // hit: 1 -> 2 is now the oldest
+	cache.ShortestPath(2, 3) // evicts 1 -> 2
+	cache.ShortestPath(1, 3) // still cached
+	cache.ShortestPath(1, 2) // miss
+	if cache.Hits != 2 || cache.Misses != 4 {
+		t.Errorf("hits, misses = %d, %d, want 2, 4", cache.Hits, cache.Misses)
+	}
+}
+
+func TestPathCacheCachesErrors(t *testing.T) {
+	cache := NewPathCache(newRoads(), 2)
+	for i := 0; i < 2; i++ {
+		if _, _, err := cache.ShortestPath(3, 1); !errors.Is(err, ErrNoPath) {
+			t.Errorf("ShortestPath(3, 1) err = %v, want ErrNoPath", err)
+		}
+	}
+	if cache.Hits != 1 || cache.Misses != 1 {
+		t.Errorf("hits, misses = %d, %d, want 1, 1", cache.Hits, cache.Misses)
+	}
+
+	uncached := NewPathCache(newRoads(), 0)
+	uncached.ShortestPath(1, 3)
+	uncached.ShortestPath(1, 3)
+	if uncached.Hits != 0 || uncached.Misses != 2 {
+		t.Errorf("capacity 0: hits, misses = %d, %d, want 0, 2", uncached.Hits, uncached.Misses)
+	}
+}
@@ -45,6 +45,16 @@ func (g *Graph) RemoveVertex(vertex int) {
 	delete(g.adjacencyList, vertex)
 }
 
+// Degree returns the number of edges touching a vertex, or -1 if it isn't in
+// the graph. A self-loop counts twice, since it touches the vertex at both ends.
+func (g *Graph) Degree(vertex int) int {
+	neighbors, ok := g.adjacencyList[vertex]
+	if !ok {
+		return -1
+	}
+	return len(neighbors)
+}
+
 // PrintGraph prints the adjacency list of the graph
 func (g *Graph) PrintGraph() {
 	for vertex, neighbors := range g.adjacencyList {
@@ -120,4 +130,7 @@ func main() {
 	graph.RemoveEdge(1, 3)
 	graph.RemoveVertex(5)
 	graph.PrintGraph()
+
+	fmt.Println("Degree of 4:", graph.Degree(4)) // 2: edges to 2 and 3
+	fmt.Println("Degree of 5:", graph.Degree(5)) // -1, removed
 }
@@ -0,0 +1,34 @@
+package main
+
+import "testing"
+
+func TestDegree(t *testing.T) {
+	g := NewGraph()
+	g.AddEdge(1, 2)
+	g.AddEdge(1, 3)
+	g.AddEdge(2, 2) // a self-loop touches 2 at both ends
+	g.AddVertex(4)
+
+	tests := []struct {
+		vertex int
+		want   int
+	}{
+		{vertex: 1, want: 2},
+		{vertex: 2, want: 3},
+		{vertex: 4, want: 0},
+		{vertex: 9, want: -1},
+	}
+	for _, tt := range tests {
+		if got := g.Degree(tt.vertex); got != tt.want {
+			t.Errorf("Degree(%d) = %d, want %d", tt.vertex, got, tt.want)
+		}
+	}
+
+	g.RemoveVertex(1)
+	if got := g.Degree(1); got != -1 {
+		t.Errorf("Degree(1) after RemoveVertex = %d, want -1", got)
+	}
+	if got := g.Degree(3); got != 0 {
+		t.Errorf("Degree(3) after RemoveVertex(1) = %d, want 0", got)
+	}
+}
@@ -1,6 +1,9 @@
 package main
 
-import "fmt"
+import (
+	"fmt"
+	"sort"
+)
 
 
 type Graph struct {
@@ -59,6 +62,115 @@ func (g Graph)Contains(key int) bool {
 	return false
 }
 
+// getVertex returns the vertex with the given key, or nil if it doesn't exist
+func (g *Graph) getVertex(key int) *vertex {
+	for _, v := range g.vertex {
+		if v.Key == key {
+			return v
+		}
+	}
+	return nil
+}
+
+// RemoveEdge removes the edge from ---> to if it exists
+func (g *Graph) RemoveEdge(from, to int) {
+	fromVertex := g.getVertex(from)
+	if fromVertex == nil {
+		return
+	}
+	for i, a := range fromVertex.adjacent {
+		if a.Key == to {
+			fromVertex.adjacent = append(fromVertex.adjacent[:i], fromVertex.adjacent[i+1:]...)
+			return
+		}
+	}
+}
+
+// sortedAdjacent returns a copy of the vertex's neighbours ordered by Key so
+// traversals don't depend on the order edges were added
+func sortedAdjacent(v *vertex) []*vertex {
+	adjacent := append([]*vertex(nil), v.adjacent...)
+	sort.Slice(adjacent, func(i, j int) bool {
+		return adjacent[i].Key < adjacent[j].Key
+	})
+	return adjacent
+}
+
+// BFS returns the keys in breadth-first order from startKey, or an empty
+// slice if startKey is not in the graph
+func (g *Graph) BFS(startKey int) []int {
+	order := []int{}
+	start := g.getVertex(startKey)
+	if start == nil {
+		return order
+	}
+
+	visited := map[*vertex]bool{start: true}
+	queue := []*vertex{start}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		order = append(order, current.Key)
+
+		for _, neighbor := range sortedAdjacent(current) {
+			if !visited[neighbor] {
+				visited[neighbor] = true
+				queue = append(queue, neighbor)
+			}
+		}
+	}
+	return order
+}
+
+// DFS returns the keys in depth-first order from startKey, or an empty
+// slice if startKey is not in the graph
+func (g *Graph) DFS(startKey int) []int {
+	order := []int{}
+	start := g.getVertex(startKey)
+	if start == nil {
+		return order
+	}
+
+	visited := make(map[*vertex]bool)
+	var visit func(v *vertex)
+	visit = func(v *vertex) {
+		visited[v] = true
+		order = append(order, v.Key)
+		for _, neighbor := range sortedAdjacent(v) {
+			if !visited[neighbor] {
+				visit(neighbor)
+			}
+		}
+	}
+	visit(start)
+	return order
+}
+
+// OutDegree returns the number of edges leaving key, or -1 if key isn't in the graph
+func (g *Graph) OutDegree(key int) int {
+	v := g.getVertex(key)
+	if v == nil {
+		return -1
+	}
+	return len(v.adjacent)
+}
+
+// InDegree returns the number of edges entering key, or -1 if key isn't in the graph
+func (g *Graph) InDegree(key int) int {
+	if g.getVertex(key) == nil {
+		return -1
+	}
+	degree := 0
+	for _, v := range g.vertex {
+		for _, a := range v.adjacent {
+			if a.Key == key {
+				degree++
+			}
+		}
+	}
+	return degree
+}
+
 func (g *Graph)PrintGraph() {
 	for _, v := range g.vertex {
 		print("Vertex ", v.Key, " : ")
@@ -79,6 +191,14 @@ func main(){
 	graph.AddVertex(1)
 	graph.AddEdge(1,2)
 	graph.AddEdge(4,3)	
+	graph.AddEdge(1, 3)
+	graph.AddEdge(3, 2)
+	fmt.Println("BFS:", graph.BFS(1))
+	fmt.Println("DFS:", graph.DFS(1))
+	fmt.Println("BFS from missing vertex:", graph.BFS(9))
+	fmt.Println("in(3):", graph.InDegree(3), "out(1):", graph.OutDegree(1)) // 1 2
+	fmt.Println("in(9):", graph.InDegree(9), "out(9):", graph.OutDegree(9)) // -1 -1
+	graph.RemoveEdge(1, 2)
 	
 graph.PrintGraph()	
 }
\ No newline at end of file
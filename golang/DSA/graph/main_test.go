@@ -0,0 +1,78 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+// newTestGraph builds 1->3, 1->2, 2->4, 3->4, 4->1 with the edges of 1 added
+// out of key order
+func newTestGraph() *Graph {
+	g := NewGraph()
+	for key := 1; key <= 4; key++ {
+		g.AddVertex(key)
+	}
+	g.AddEdge(1, 3)
+	g.AddEdge(1, 2)
+	g.AddEdge(2, 4)
+	g.AddEdge(3, 4)
+	g.AddEdge(4, 1)
+	return g
+}
+
+func TestGraphTraversalOrder(t *testing.T) {
+	g := newTestGraph()
+	if got, want := g.BFS(1), []int{1, 2, 3, 4}; !reflect.DeepEqual(got, want) {
+		t.Errorf("BFS(1) = %v, want %v", got, want)
+	}
+	if got, want := g.DFS(1), []int{1, 2, 4, 3}; !reflect.DeepEqual(got, want) {
+		t.Errorf("DFS(1) = %v, want %v", got, want)
+	}
+	if got, want := g.BFS(3), []int{3, 4, 1, 2}; !reflect.DeepEqual(got, want) {
+		t.Errorf("BFS(3) = %v, want %v", got, want)
+	}
+}
+
+func TestGraphTraversalInvalidStart(t *testing.T) {
+	g := newTestGraph()
+	if got := g.BFS(9); got == nil || len(got) != 0 {
+		t.Errorf("BFS(9) = %#v, want an empty slice", got)
+	}
+	if got := g.DFS(9); got == nil || len(got) != 0 {
+		t.Errorf("DFS(9) = %#v, want an empty slice", got)
+	}
+}
+
+func TestGraphRemoveEdge(t *testing.T) {
+	g := newTestGraph()
+	g.RemoveEdge(1, 2)
+	g.RemoveEdge(1, 9) // missing edges are ignored
+	g.RemoveEdge(9, 1)
+	if got, want := g.BFS(1), []int{1, 3, 4}; !reflect.DeepEqual(got, want) {
+		t.Errorf("BFS(1) after RemoveEdge(1, 2) = %v, want %v", got, want)
+	}
+}
+
+func TestGraphDegrees(t *testing.T) {
+	g := newTestGraph()
+	g.AddVertex(5)
+
+	tests := []struct {
+		key     int
+		wantIn  int
+		wantOut int
+	}{
+		{key: 1, wantIn: 1, wantOut: 2},
+		{key: 4, wantIn: 2, wantOut: 1},
+		{key: 5, wantIn: 0, wantOut: 0},   // exists with no edges
+		{key: 9, wantIn: -1, wantOut: -1}, // not in the graph
+	}
+	for _, tt := range tests {
+		if got := g.InDegree(tt.key); got != tt.wantIn {
+			t.Errorf("InDegree(%d) = %d, want %d", tt.key, got, tt.wantIn)
+		}
+		if got := g.OutDegree(tt.key); got != tt.wantOut {
+			t.Errorf("OutDegree(%d) = %d, want %d", tt.key, got, tt.wantOut)
+		}
+	}
+}
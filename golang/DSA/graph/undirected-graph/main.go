@@ -1,17 +1,59 @@
 package main
 
-import "fmt"
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+)
 
 //Undirected UnDirectedGraph implementation using adjacency list
 type UnDirectedGraph struct {
-	AdjacencyList  map[int][]int
+	AdjacencyList map[int][]int
+	weights       map[edgeKey]float64 // weights set by AddWeightedEdge
+}
+
+// Edge is an undirected edge with a weight
+type Edge struct {
+	From   int     `json:"from"`
+	To     int     `json:"to"`
+	Weight float64 `json:"weight"`
+}
+
+// edgeKey identifies an undirected edge independent of direction
+type edgeKey struct{ a, b int }
+
+func newEdgeKey(v1, v2 int) edgeKey {
+	if v1 > v2 {
+		v1, v2 = v2, v1
+	}
+	return edgeKey{v1, v2}
 }
 
 //NewUnDirectedGraph creates a new UnDirectedGraph
 func NewUnDirectedGraph() *UnDirectedGraph {
 	return &UnDirectedGraph{
 		AdjacencyList: make(map[int][]int),
+		weights:       make(map[edgeKey]float64),
+	}
+}
+
+// AddWeightedEdge adds an edge between v1 and v2 with the given weight.
+// Edges added with AddEdge have weight 1.
+func (g *UnDirectedGraph) AddWeightedEdge(v1, v2 int, weight float64) {
+	g.AddEdge(v1, v2)
+	if g.weights == nil {
+		g.weights = make(map[edgeKey]float64)
+	}
+	g.weights[newEdgeKey(v1, v2)] = weight
+}
+
+// Weight returns the weight of the edge between v1 and v2
+func (g *UnDirectedGraph) Weight(v1, v2 int) float64 {
+	if w, ok := g.weights[newEdgeKey(v1, v2)]; ok {
+		return w
 	}
+	return 1
 }
 
 //AddVertex adds a vertex to the UnDirectedGraph	
@@ -42,6 +84,7 @@ func (g *UnDirectedGraph) PrintUnDirectedGraph() {
 func (g *UnDirectedGraph) RemoveEdge(v1, v2 int) {
 	g.AdjacencyList[v1] = removeFromSlice(g.AdjacencyList[v1], v2)
 	g.AdjacencyList[v2] = removeFromSlice(g.AdjacencyList[v2], v1)
+	delete(g.weights, newEdgeKey(v1, v2))
 }
 
 func removeFromSlice(slice []int, value int) []int {
@@ -57,6 +100,7 @@ func removeFromSlice(slice []int, value int) []int {
 func (g *UnDirectedGraph) RemoveVertex(vertex int) {
 	for _, neighbor := range g.AdjacencyList[vertex] {
 		g.AdjacencyList[neighbor] = removeFromSlice(g.AdjacencyList[neighbor], vertex)
+		delete(g.weights, newEdgeKey(vertex, neighbor))
 	}
 	delete(g.AdjacencyList, vertex)
 }
@@ -83,6 +127,420 @@ func (g *UnDirectedGraph) BFS(start int) {
 	fmt.Println()
 }
 
+// HasEdge reports whether v1 and v2 are connected by an edge
+func (g *UnDirectedGraph) HasEdge(v1, v2 int) bool {
+	for _, neighbor := range g.AdjacencyList[v1] {
+		if neighbor == v2 {
+			return true
+		}
+	}
+	return false
+}
+
+// Neighbors returns the vertices connected to v, sorted. The slice is a copy,
+// so changing it doesn't change the graph.
+func (g *UnDirectedGraph) Neighbors(v int) []int {
+	neighbors := append([]int{}, g.AdjacencyList[v]...)
+	sort.Ints(neighbors)
+	return neighbors
+}
+
+// Vertices returns the graph's vertices in ascending order
+func (g *UnDirectedGraph) Vertices() []int {
+	vertices := make([]int, 0, len(g.AdjacencyList))
+	for v := range g.AdjacencyList {
+		vertices = append(vertices, v)
+	}
+	sort.Ints(vertices)
+	return vertices
+}
+
+// BFSOrder returns the vertices reachable from start in breadth-first order,
+// visiting each vertex's neighbors in ascending order
+func (g *UnDirectedGraph) BFSOrder(start int) []int {
+	order, _ := g.BFSOrderCtx(context.Background(), start)
+	return order
+}
+
+// ctxCheckInterval is how many vertices a cancellable traversal visits
+// between checks of its context
+const ctxCheckInterval = 1024
+
+// BFSOrderCtx is BFSOrder that can be aborted. Every ctxCheckInterval vertices
+// it checks ctx, and if ctx is done it returns the order so far with ctx.Err().
+func (g *UnDirectedGraph) BFSOrderCtx(ctx context.Context, start int) ([]int, error) {
+	order := []int{}
+	if _, ok := g.AdjacencyList[start]; !ok {
+		return order, nil
+	}
+	visited := map[int]bool{start: true}
+	queue := []int{start}
+	for len(queue) > 0 {
+		if len(order)%ctxCheckInterval == 0 {
+			if err := ctx.Err(); err != nil {
+				return order, err
+			}
+		}
+		current := queue[0]
+		queue = queue[1:]
+		order = append(order, current)
+		for _, neighbor := range g.Neighbors(current) {
+			if !visited[neighbor] {
+				visited[neighbor] = true
+				queue = append(queue, neighbor)
+			}
+		}
+	}
+	return order, nil
+}
+
+// DFSOrderCtx returns the vertices reachable from start in depth-first order,
+// visiting each vertex's neighbors in ascending order. It uses an explicit
+// stack, so deep graphs can't overflow the goroutine stack. Every
+// ctxCheckInterval vertices it checks ctx, and if ctx is done it returns the
+// order so far with ctx.Err().
+func (g *UnDirectedGraph) DFSOrderCtx(ctx context.Context, start int) ([]int, error) {
+	order := []int{}
+	if _, ok := g.AdjacencyList[start]; !ok {
+		return order, nil
+	}
+	type frame struct {
+		neighbors []int
+		next      int // index of the next neighbor to look at
+	}
+	if err := ctx.Err(); err != nil {
+		return order, err
+	}
+	visited := map[int]bool{start: true}
+	order = append(order, start)
+	stack := []frame{{neighbors: g.Neighbors(start)}}
+	for len(stack) > 0 {
+		top := &stack[len(stack)-1]
+		if top.next == len(top.neighbors) {
+			stack = stack[:len(stack)-1]
+			continue
+		}
+		neighbor := top.neighbors[top.next]
+		top.next++
+		if !visited[neighbor] {
+			if len(order)%ctxCheckInterval == 0 {
+				if err := ctx.Err(); err != nil {
+					return order, err
+				}
+			}
+			visited[neighbor] = true
+			order = append(order, neighbor)
+			stack = append(stack, frame{neighbors: g.Neighbors(neighbor)})
+		}
+	}
+	return order, nil
+}
+
+// Distances returns the fewest number of edges from start to every vertex it
+// can reach, found by BFS. start itself is at distance 0; unreachable
+// vertices are left out. A missing start gives an empty map.
+func (g *UnDirectedGraph) Distances(start int) map[int]int {
+	dist := make(map[int]int)
+	if _, ok := g.AdjacencyList[start]; !ok {
+		return dist
+	}
+	dist[start] = 0
+	queue := []int{start}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		for _, neighbor := range g.AdjacencyList[current] {
+			if _, seen := dist[neighbor]; !seen {
+				dist[neighbor] = dist[current] + 1
+				queue = append(queue, neighbor)
+			}
+		}
+	}
+	return dist
+}
+
+// Degree returns the number of edges touching v, or -1 if v isn't in the graph.
+// A self-loop counts twice, since it touches v at both ends.
+func (g *UnDirectedGraph) Degree(v int) int {
+	neighbors, ok := g.AdjacencyList[v]
+	if !ok {
+		return -1
+	}
+	return len(neighbors) // AddEdge(v, v) appends v to its own list twice
+}
+
+// GreedyColoring assigns each vertex a color 0, 1, 2, ... so that no two
+// adjacent vertices share one. Vertices are colored in descending order of
+// degree (Welsh-Powell), ties by ascending vertex, and each takes the
+// smallest color none of its colored neighbors has. This is an
+// approximation: it never uses more than max degree + 1 colors, but may use
+// more than the true minimum. Self-loops are ignored since no coloring can
+// satisfy them.
+func (g *UnDirectedGraph) GreedyColoring() map[int]int {
+	order := g.Vertices()
+	sort.SliceStable(order, func(i, j int) bool {
+		return g.Degree(order[i]) > g.Degree(order[j])
+	})
+
+	colors := make(map[int]int, len(order))
+	for _, v := range order {
+		used := make(map[int]bool)
+		for _, neighbor := range g.AdjacencyList[v] {
+			if c, colored := colors[neighbor]; colored && neighbor != v {
+				used[c] = true
+			}
+		}
+		color := 0
+		for used[color] {
+			color++
+		}
+		colors[v] = color
+	}
+	return colors
+}
+
+// ChromaticEstimate returns the number of colors GreedyColoring uses, an
+// upper bound on the chromatic number. An empty graph needs 0.
+func (g *UnDirectedGraph) ChromaticEstimate() int {
+	count := 0
+	for _, c := range g.GreedyColoring() {
+		count = max(count, c+1)
+	}
+	return count
+}
+
+// graphJSON is the wire format for an UnDirectedGraph. The adjacency list is
+// stored as is, so every edge appears under both of its endpoints.
+type graphJSON struct {
+	Adjacency map[int][]int `json:"adjacency"`
+	Weights   []Edge        `json:"weights,omitempty"`
+}
+
+// MarshalJSON encodes the adjacency list, including vertices with no edges
+func (g *UnDirectedGraph) MarshalJSON() ([]byte, error) {
+	out := graphJSON{Adjacency: make(map[int][]int, len(g.AdjacencyList))}
+	for v, neighbors := range g.AdjacencyList {
+		out.Adjacency[v] = append([]int{}, neighbors...)
+	}
+	for key, w := range g.weights {
+		out.Weights = append(out.Weights, Edge{From: key.a, To: key.b, Weight: w})
+	}
+	sort.Slice(out.Weights, func(i, j int) bool {
+		if out.Weights[i].From != out.Weights[j].From {
+			return out.Weights[i].From < out.Weights[j].From
+		}
+		return out.Weights[i].To < out.Weights[j].To
+	})
+	return json.Marshal(out)
+}
+
+// UnmarshalJSON replaces the graph with the one encoded by MarshalJSON. It
+// returns an error if an edge is missing its entry under the other endpoint.
+func (g *UnDirectedGraph) UnmarshalJSON(data []byte) error {
+	var in graphJSON
+	if err := json.Unmarshal(data, &in); err != nil {
+		return err
+	}
+	counts := make(map[edgeKey]int) // +1 for v1 -> v2, -1 for v2 -> v1
+	for v, neighbors := range in.Adjacency {
+		for _, n := range neighbors {
+			switch {
+			case v < n:
+				counts[edgeKey{v, n}]++
+			case v > n:
+				counts[edgeKey{n, v}]--
+			}
+		}
+	}
+	for key, c := range counts {
+		if c != 0 {
+			return fmt.Errorf("edge %d - %d is not listed under both vertices", key.a, key.b)
+		}
+	}
+
+	*g = *NewUnDirectedGraph()
+	for v, neighbors := range in.Adjacency {
+		g.AdjacencyList[v] = append([]int{}, neighbors...)
+	}
+	for _, e := range in.Weights {
+		g.weights[newEdgeKey(e.From, e.To)] = e.Weight
+	}
+	return nil
+}
+
+// MatrixGraph is an undirected graph stored as an adjacency matrix: cell
+// [i][j] is true when the vertices at indices i and j share an edge. Checking
+// for an edge is O(1), but the matrix takes O(V²) space however few edges
+// there are, and listing a vertex's neighbors is O(V). It suits dense graphs;
+// the adjacency list in UnDirectedGraph, at O(V+E), suits sparse ones.
+type MatrixGraph struct {
+	index    map[int]int // vertex -> row/column in matrix
+	vertices []int       // row/column -> vertex
+	matrix   [][]bool
+}
+
+func NewMatrixGraph() *MatrixGraph {
+	return &MatrixGraph{index: make(map[int]int)}
+}
+
+// FromAdjacencyList builds a MatrixGraph with the same vertices and edges as g
+func FromAdjacencyList(g *UnDirectedGraph) *MatrixGraph {
+	m := NewMatrixGraph()
+	for _, v := range g.Vertices() {
+		m.AddVertex(v)
+	}
+	for v, neighbors := range g.AdjacencyList {
+		for _, n := range neighbors {
+			m.AddEdge(v, n)
+		}
+	}
+	return m
+}
+
+// AddVertex adds a vertex, growing the matrix by one row and column
+func (m *MatrixGraph) AddVertex(v int) {
+	if _, ok := m.index[v]; ok {
+		return
+	}
+	m.index[v] = len(m.vertices)
+	m.vertices = append(m.vertices, v)
+	for i := range m.matrix {
+		m.matrix[i] = append(m.matrix[i], false)
+	}
+	m.matrix = append(m.matrix, make([]bool, len(m.vertices)))
+}
+
+// AddEdge connects v1 and v2, adding either vertex if needed
+func (m *MatrixGraph) AddEdge(v1, v2 int) {
+	m.AddVertex(v1)
+	m.AddVertex(v2)
+	i, j := m.index[v1], m.index[v2]
+	m.matrix[i][j] = true
+	m.matrix[j][i] = true
+}
+
+// HasEdge reports whether v1 and v2 are connected, in O(1)
+func (m *MatrixGraph) HasEdge(v1, v2 int) bool {
+	i, ok1 := m.index[v1]
+	j, ok2 := m.index[v2]
+	return ok1 && ok2 && m.matrix[i][j]
+}
+
+// Neighbors returns the vertices connected to v, sorted
+func (m *MatrixGraph) Neighbors(v int) []int {
+	neighbors := []int{}
+	i, ok := m.index[v]
+	if !ok {
+		return neighbors
+	}
+	for j, connected := range m.matrix[i] {
+		if connected {
+			neighbors = append(neighbors, m.vertices[j])
+		}
+	}
+	sort.Ints(neighbors)
+	return neighbors
+}
+
+// BFSOrder returns the vertices reachable from start in breadth-first order,
+// visiting each vertex's neighbors in ascending order
+func (m *MatrixGraph) BFSOrder(start int) []int {
+	order := []int{}
+	if _, ok := m.index[start]; !ok {
+		return order
+	}
+	visited := map[int]bool{start: true}
+	queue := []int{start}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		order = append(order, current)
+		for _, neighbor := range m.Neighbors(current) {
+			if !visited[neighbor] {
+				visited[neighbor] = true
+				queue = append(queue, neighbor)
+			}
+		}
+	}
+	return order
+}
+
+// unionFind tracks which vertices are already connected while building a
+// spanning tree
+type unionFind struct {
+	parent map[int]int
+	rank   map[int]int
+}
+
+func newUnionFind() *unionFind {
+	return &unionFind{parent: make(map[int]int), rank: make(map[int]int)}
+}
+
+func (u *unionFind) find(x int) int {
+	if _, ok := u.parent[x]; !ok {
+		u.parent[x] = x
+	}
+	if u.parent[x] != x {
+		u.parent[x] = u.find(u.parent[x]) // path compression
+	}
+	return u.parent[x]
+}
+
+// union joins the sets of a and b and reports false if they were already joined
+func (u *unionFind) union(a, b int) bool {
+	rootA, rootB := u.find(a), u.find(b)
+	if rootA == rootB {
+		return false
+	}
+	switch {
+	case u.rank[rootA] < u.rank[rootB]:
+		u.parent[rootA] = rootB
+	case u.rank[rootA] > u.rank[rootB]:
+		u.parent[rootB] = rootA
+	default:
+		u.parent[rootB] = rootA
+		u.rank[rootA]++
+	}
+	return true
+}
+
+// MinimumSpanningTree returns the edges of a minimum spanning tree and their
+// total weight using Kruskal's algorithm: edges are taken cheapest first and
+// kept only if they join two vertices that aren't connected yet. If the graph
+// is disconnected the result is a minimum spanning forest, one tree per
+// connected component. Isolated vertices and self-loops contribute no edges.
+func (g *UnDirectedGraph) MinimumSpanningTree() ([]Edge, float64) {
+	var edges []Edge
+	for v, neighbors := range g.AdjacencyList {
+		for _, n := range neighbors {
+			if v < n { // each undirected edge is stored twice; take it once
+				edges = append(edges, Edge{From: v, To: n, Weight: g.Weight(v, n)})
+			}
+		}
+	}
+	// Break ties by endpoints so the result doesn't depend on map order
+	sort.Slice(edges, func(i, j int) bool {
+		if edges[i].Weight != edges[j].Weight {
+			return edges[i].Weight < edges[j].Weight
+		}
+		if edges[i].From != edges[j].From {
+			return edges[i].From < edges[j].From
+		}
+		return edges[i].To < edges[j].To
+	})
+
+	var tree []Edge
+	total := 0.0
+	sets := newUnionFind()
+	for _, e := range edges {
+		if sets.union(e.From, e.To) {
+			tree = append(tree, e)
+			total += e.Weight
+		}
+	}
+	return tree, total
+}
+
 func main() {
 	UnDirectedGraph := NewUnDirectedGraph()
 	UnDirectedGraph.AddEdge(1, 2)
@@ -95,4 +553,76 @@ func main() {
 	UnDirectedGraph.PrintUnDirectedGraph()
 	UnDirectedGraph.BFS(1)
 	UnDirectedGraph.BFS(5)
+
+	weighted := NewUnDirectedGraph()
+	weighted.AddWeightedEdge(1, 2, 4)
+	weighted.AddWeightedEdge(1, 3, 1)
+	weighted.AddWeightedEdge(2, 3, 2)
+	weighted.AddWeightedEdge(2, 4, 5)
+	weighted.AddWeightedEdge(3, 4, 8)
+	weighted.AddWeightedEdge(6, 7, 3) // separate component
+	tree, cost := weighted.MinimumSpanningTree()
+	fmt.Printf("MST: %v cost=%.1f\n", tree, cost) // cost 1+2+5+3 = 11
+
+	weighted.AddVertex(8)
+	weighted.AddEdge(6, 6)
+	fmt.Println("degree(2):", weighted.Degree(2)) // 3
+	fmt.Println("degree(6):", weighted.Degree(6)) // 3: edge to 7 plus a self-loop
+	fmt.Println("degree(8):", weighted.Degree(8)) // 0
+	fmt.Println("degree(9):", weighted.Degree(9)) // -1
+
+	// JSON round trip keeps isolated vertex 8 and both entries of each edge
+	data, err := json.Marshal(weighted)
+	if err != nil {
+		fmt.Println("marshal:", err)
+		return
+	}
+	fmt.Println("JSON:", string(data))
+	restored := NewUnDirectedGraph()
+	if err := json.Unmarshal(data, restored); err != nil {
+		fmt.Println("unmarshal:", err)
+		return
+	}
+	restoredTree, restoredCost := restored.MinimumSpanningTree()
+	fmt.Printf("restored MST: %v cost=%.1f degree(8)=%d\n", restoredTree, restoredCost, restored.Degree(8))
+	broken := []byte(`{"adjacency":{"1":[2],"2":[]}}`)
+	fmt.Println("asymmetric:", json.Unmarshal(broken, restored))
+
+	fmt.Println("vertices:", weighted.Vertices(), "neighbors(2):", weighted.Neighbors(2))
+	fmt.Println("has 1-2:", weighted.HasEdge(1, 2), "has 2-1:", weighted.HasEdge(2, 1), "has 1-4:", weighted.HasEdge(1, 4))
+
+	// 6-7 and 8 are separate components, so they are absent from the map
+	fmt.Println("distances from 1:", weighted.Distances(1)) // map[1:0 2:1 3:1 4:2]
+	fmt.Println("distances from 8:", weighted.Distances(8)) // map[8:0]
+
+	// The matrix form of the same graph gives the same BFS order
+	matrix := FromAdjacencyList(weighted)
+	fmt.Println("BFS from 1 list:", weighted.BFSOrder(1), "matrix:", matrix.BFSOrder(1))
+	fmt.Println("matrix has 1-2:", matrix.HasEdge(1, 2), "has 1-4:", matrix.HasEdge(1, 4))
+
+	// An odd cycle can't alternate two colors, so greedy coloring needs three
+	ring := NewUnDirectedGraph()
+	for i := 0; i < 5; i++ {
+		ring.AdjacencyList[i] = []int{(i + 4) % 5, (i + 1) % 5}
+	}
+	fmt.Println("coloring of a 5-cycle:", ring.GreedyColoring(), "colors:", ring.ChromaticEstimate())
+
+	dfsOrder, _ := weighted.DFSOrderCtx(context.Background(), 1)
+	fmt.Println("DFS from 1:", dfsOrder)
+
+	// A long path 0 - 1 - ... - 99999, built directly to skip AddEdge's output
+	const length = 100000
+	path := NewUnDirectedGraph()
+	for i := 0; i < length; i++ {
+		path.AdjacencyList[i] = append(path.AdjacencyList[i], i+1)
+		path.AdjacencyList[i+1] = append(path.AdjacencyList[i+1], i)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	partialBFS, err := path.BFSOrderCtx(ctx, 0)
+	fmt.Println("cancelled BFS visited:", len(partialBFS), "err:", err)
+	partialDFS, err := path.DFSOrderCtx(ctx, 0)
+	fmt.Println("cancelled DFS visited:", len(partialDFS), "err:", err)
+	fullDFS, err := path.DFSOrderCtx(context.Background(), 0)
+	fmt.Println("uncancelled DFS visited:", len(fullDFS), "err:", err)
 }
@@ -0,0 +1,315 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+// newWeightedGraph builds two components: a four-vertex graph whose MST
+// costs 1+2+5 and a single 6-7 edge of weight 3
+func newWeightedGraph() *UnDirectedGraph {
+	g := NewUnDirectedGraph()
+	g.AddWeightedEdge(1, 2, 4)
+	g.AddWeightedEdge(1, 3, 1)
+	g.AddWeightedEdge(2, 3, 2)
+	g.AddWeightedEdge(2, 4, 5)
+	g.AddWeightedEdge(3, 4, 8)
+	g.AddWeightedEdge(6, 7, 3)
+	return g
+}
+
+func TestMinimumSpanningTree(t *testing.T) {
+	tree, cost := newWeightedGraph().MinimumSpanningTree()
+
+	want := []Edge{
+		{From: 1, To: 3, Weight: 1},
+		{From: 2, To: 3, Weight: 2},
+		{From: 6, To: 7, Weight: 3},
+		{From: 2, To: 4, Weight: 5},
+	}
+	if !reflect.DeepEqual(tree, want) {
+		t.Errorf("MinimumSpanningTree() edges = %v, want %v", tree, want)
+	}
+	if cost != 11 {
+		t.Errorf("MinimumSpanningTree() cost = %v, want 11", cost)
+	}
+}
+
+func TestMinimumSpanningTreeEmpty(t *testing.T) {
+	tree, cost := NewUnDirectedGraph().MinimumSpanningTree()
+	if len(tree) != 0 || cost != 0 {
+		t.Errorf("MinimumSpanningTree() on empty graph = %v, %v, want no edges and 0", tree, cost)
+	}
+}
+
+func TestDegree(t *testing.T) {
+	g := newWeightedGraph()
+	g.AddVertex(8)
+	g.AddEdge(6, 6)
+
+	tests := []struct {
+		vertex int
+		want   int
+	}{
+		{vertex: 2, want: 3},
+		{vertex: 6, want: 3}, // the edge to 7 plus a self-loop counted twice
+		{vertex: 8, want: 0},
+		{vertex: 9, want: -1},
+	}
+	for _, tt := range tests {
+		if got := g.Degree(tt.vertex); got != tt.want {
+			t.Errorf("Degree(%d) = %d, want %d", tt.vertex, got, tt.want)
+		}
+	}
+}
+
+func TestJSONRoundTrip(t *testing.T) {
+	g := newWeightedGraph()
+	g.AddVertex(8)
+
+	data, err := json.Marshal(g)
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+	restored := NewUnDirectedGraph()
+	if err := json.Unmarshal(data, restored); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+
+	// Both entries of each edge and the isolated vertex 8 survive
+	if !reflect.DeepEqual(restored.AdjacencyList, g.AdjacencyList) {
+		t.Errorf("restored adjacency = %v, want %v", restored.AdjacencyList, g.AdjacencyList)
+	}
+	if !reflect.DeepEqual(restored.weights, g.weights) {
+		t.Errorf("restored weights = %v, want %v", restored.weights, g.weights)
+	}
+}
+
+func TestUnmarshalJSONRejectsAsymmetricEdge(t *testing.T) {
+	g := NewUnDirectedGraph()
+	if err := json.Unmarshal([]byte(`{"adjacency":{"1":[2],"2":[]}}`), g); err == nil {
+		t.Error("Unmarshal of an edge listed under one endpoint returned nil error")
+	}
+}
+
+func TestAccessors(t *testing.T) {
+	g := newWeightedGraph()
+
+	if got, want := g.Vertices(), []int{1, 2, 3, 4, 6, 7}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Vertices() = %v, want %v", got, want)
+	}
+	if !g.HasEdge(1, 2) || !g.HasEdge(2, 1) || g.HasEdge(1, 4) {
+		t.Error("HasEdge is not symmetric or reports a missing edge")
+	}
+
+	neighbors := g.Neighbors(2)
+	if want := []int{1, 3, 4}; !reflect.DeepEqual(neighbors, want) {
+		t.Errorf("Neighbors(2) = %v, want %v", neighbors, want)
+	}
+	neighbors[0] = 99 // a copy: the graph is unchanged
+	if !g.HasEdge(2, 1) || g.HasEdge(2, 99) {
+		t.Error("changing the slice from Neighbors changed the graph")
+	}
+}
+
+func TestDistances(t *testing.T) {
+	g := newWeightedGraph()
+	g.AddVertex(8)
+
+	tests := []struct {
+		start int
+		want  map[int]int
+	}{
+		// 6-7 and 8 are other components, so they are left out
+		{start: 1, want: map[int]int{1: 0, 2: 1, 3: 1, 4: 2}},
+		{start: 4, want: map[int]int{4: 0, 2: 1, 3: 1, 1: 2}},
+		{start: 7, want: map[int]int{7: 0, 6: 1}},
+		{start: 8, want: map[int]int{8: 0}},
+		{start: 9, want: map[int]int{}},
+	}
+	for _, tt := range tests {
+		if got := g.Distances(tt.start); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("Distances(%d) = %v, want %v", tt.start, got, tt.want)
+		}
+	}
+}
+
+func TestMatrixGraphMatchesAdjacencyList(t *testing.T) {
+	g := newWeightedGraph()
+	g.AddVertex(8)
+	matrix := FromAdjacencyList(g)
+
+	for _, start := range []int{1, 2, 4, 6, 7, 8, 9} {
+		listOrder, matrixOrder := g.BFSOrder(start), matrix.BFSOrder(start)
+		if !reflect.DeepEqual(matrixOrder, listOrder) {
+			t.Errorf("BFSOrder(%d): matrix = %v, list = %v", start, matrixOrder, listOrder)
+		}
+	}
+	for _, v := range []int{1, 2, 3, 4, 6, 7, 8} {
+		if got, want := matrix.Neighbors(v), g.Neighbors(v); !reflect.DeepEqual(got, want) {
+			t.Errorf("Neighbors(%d): matrix = %v, list = %v", v, got, want)
+		}
+		for _, u := range []int{1, 2, 3, 4, 6, 7, 8} {
+			if matrix.HasEdge(v, u) != g.HasEdge(v, u) {
+				t.Errorf("HasEdge(%d, %d): matrix = %v, list = %v", v, u, matrix.HasEdge(v, u), g.HasEdge(v, u))
+			}
+		}
+	}
+}
+
+func TestMatrixGraphAddEdge(t *testing.T) {
+	m := NewMatrixGraph()
+	m.AddEdge(1, 2)
+	m.AddEdge(2, 3)
+	m.AddVertex(5)
+
+	if !m.HasEdge(2, 1) || m.HasEdge(1, 3) || m.HasEdge(1, 9) {
+		t.Errorf("HasEdge: 2-1 = %v, 1-3 = %v, 1-9 = %v, want true, false, false",
+			m.HasEdge(2, 1), m.HasEdge(1, 3), m.HasEdge(1, 9))
+	}
+	if got := m.Neighbors(2); !reflect.DeepEqual(got, []int{1, 3}) {
+		t.Errorf("Neighbors(2) = %v, want [1 3]", got)
+	}
+	if got := m.Neighbors(5); len(got) != 0 {
+		t.Errorf("Neighbors(5) = %v, want none", got)
+	}
+	if got := m.BFSOrder(3); !reflect.DeepEqual(got, []int{3, 2, 1}) {
+		t.Errorf("BFSOrder(3) = %v, want [3 2 1]", got)
+	}
+}
+
+// newPath builds 0 - 1 - ... - length directly, skipping AddEdge's output
+func newPath(length int) *UnDirectedGraph {
+	g := NewUnDirectedGraph()
+	for i := 0; i < length; i++ {
+		g.AdjacencyList[i] = append(g.AdjacencyList[i], i+1)
+		g.AdjacencyList[i+1] = append(g.AdjacencyList[i+1], i)
+	}
+	return g
+}
+
+// cancelAfterChecks is a context that reports itself cancelled once Err has
+// been called checks times, so a test can cancel a traversal part way through
+type cancelAfterChecks struct {
+	context.Context
+	checks int
+}
+
+func (c *cancelAfterChecks) Err() error {
+	if c.checks == 0 {
+		return context.Canceled
+	}
+	c.checks--
+	return nil
+}
+
+func TestTraversalCtxCancellation(t *testing.T) {
+	const length = 10 * ctxCheckInterval
+	path := newPath(length)
+	traversals := []struct {
+		name string
+		run  func(context.Context, int) ([]int, error)
+	}{
+		{name: "BFSOrderCtx", run: path.BFSOrderCtx},
+		{name: "DFSOrderCtx", run: path.DFSOrderCtx},
+	}
+	for _, tr := range traversals {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		order, err := tr.run(ctx, 0)
+		if !errors.Is(err, context.Canceled) || len(order) != 0 {
+			t.Errorf("%s with a cancelled ctx = %d vertices, %v, want 0, context.Canceled", tr.name, len(order), err)
+		}
+
+		// The checks at 0, 1, and 2 intervals pass and the fourth finds ctx
+		// done, so the traversal stops with three intervals' worth of vertices
+		order, err = tr.run(&cancelAfterChecks{Context: context.Background(), checks: 3}, 0)
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("%s cancelled part way: err = %v, want context.Canceled", tr.name, err)
+		}
+		if len(order) != 3*ctxCheckInterval {
+			t.Errorf("%s cancelled part way visited %d vertices, want %d", tr.name, len(order), 3*ctxCheckInterval)
+		}
+
+		order, err = tr.run(context.Background(), 0)
+		if err != nil || len(order) != length+1 {
+			t.Errorf("%s uncancelled = %d vertices, %v, want %d, nil", tr.name, len(order), err, length+1)
+		}
+	}
+}
+
+// newCycle builds the ring 0 - 1 - ... - n-1 - 0
+func newCycle(n int) *UnDirectedGraph {
+	ring := NewUnDirectedGraph()
+	for i := 0; i < n; i++ {
+		ring.AdjacencyList[i] = []int{(i + n - 1) % n, (i + 1) % n}
+	}
+	return ring
+}
+
+// checkProperColoring reports any vertex left uncolored or sharing a color
+// with a neighbor other than itself
+func checkProperColoring(t *testing.T, name string, g *UnDirectedGraph, coloring map[int]int) {
+	t.Helper()
+	for v, neighbors := range g.AdjacencyList {
+		if _, ok := coloring[v]; !ok {
+			t.Errorf("%s: vertex %d has no color", name, v)
+		}
+		for _, neighbor := range neighbors {
+			if neighbor != v && coloring[v] == coloring[neighbor] {
+				t.Errorf("%s: neighbors %d and %d share color %d", name, v, neighbor, coloring[v])
+			}
+		}
+	}
+}
+
+func TestGreedyColoringCycles(t *testing.T) {
+	// An even cycle alternates two colors; an odd cycle needs a third
+	tests := []struct {
+		n    int
+		want int
+	}{
+		{n: 3, want: 3},
+		{n: 4, want: 2},
+		{n: 5, want: 3},
+		{n: 6, want: 2},
+		{n: 7, want: 3},
+		{n: 100, want: 2},
+		{n: 101, want: 3},
+	}
+	for _, tt := range tests {
+		ring := newCycle(tt.n)
+		name := fmt.Sprintf("cycle of %d", tt.n)
+		checkProperColoring(t, name, ring, ring.GreedyColoring())
+		if got := ring.ChromaticEstimate(); got != tt.want {
+			t.Errorf("%s: ChromaticEstimate() = %d, want %d", name, got, tt.want)
+		}
+	}
+}
+
+func TestGreedyColoring(t *testing.T) {
+	// The triangle 1-2-3 needs three colors; the self-loop on 6 is ignored
+	weighted := newWeightedGraph()
+	weighted.AddVertex(8)
+	weighted.AddEdge(6, 6)
+	coloring := weighted.GreedyColoring()
+	checkProperColoring(t, "weighted graph", weighted, coloring)
+	if len(coloring) != 7 {
+		t.Errorf("colored %d vertices, want 7", len(coloring))
+	}
+	if got := weighted.ChromaticEstimate(); got != 3 {
+		t.Errorf("ChromaticEstimate() = %d, want 3", got)
+	}
+
+	empty := NewUnDirectedGraph()
+	if coloring := empty.GreedyColoring(); len(coloring) != 0 {
+		t.Errorf("empty graph coloring = %v, want none", coloring)
+	}
+	if got := empty.ChromaticEstimate(); got != 0 {
+		t.Errorf("empty graph ChromaticEstimate() = %d, want 0", got)
+	}
+}
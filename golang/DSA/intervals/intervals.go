@@ -0,0 +1,50 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+)
+
+// MergeIntervals sorts intervals by start and merges any that overlap or
+// touch, so [1,3] and [3,5] become [1,5] but [1,2] and [3,4] stay apart.
+// The input slice is not modified.
+func MergeIntervals(intervals [][2]int) [][2]int {
+	merged := [][2]int{}
+	if len(intervals) == 0 {
+		return merged
+	}
+	sorted := append([][2]int(nil), intervals...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i][0] < sorted[j][0] })
+
+	merged = append(merged, sorted[0])
+	for _, next := range sorted[1:] {
+		last := &merged[len(merged)-1]
+		if next[0] <= last[1] {
+			last[1] = max(last[1], next[1]) // also covers fully contained intervals
+		} else {
+			merged = append(merged, next)
+		}
+	}
+	return merged
+}
+
+// InsertInterval adds newInterval to a sorted list of non-overlapping
+// intervals, merging where needed, and returns a new sorted list
+func InsertInterval(sorted [][2]int, newInterval [2]int) [][2]int {
+	result := make([][2]int, 0, len(sorted)+1)
+	i := 0
+	for ; i < len(sorted) && sorted[i][1] < newInterval[0]; i++ {
+		result = append(result, sorted[i]) // ends before the new interval
+	}
+	for ; i < len(sorted) && sorted[i][0] <= newInterval[1]; i++ {
+		newInterval[0] = min(newInterval[0], sorted[i][0])
+		newInterval[1] = max(newInterval[1], sorted[i][1])
+	}
+	result = append(result, newInterval)
+	return append(result, sorted[i:]...)
+}
+
+func main() {
+	fmt.Println(MergeIntervals([][2]int{{1, 3}, {2, 6}, {8, 10}, {15, 18}}))             // [[1 6] [8 10] [15 18]]
+	fmt.Println(InsertInterval([][2]int{{1, 2}, {3, 5}, {6, 7}, {8, 10}}, [2]int{4, 8})) // [[1 2] [3 10]]
+}
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestMergeIntervals(t *testing.T) {
+	tests := []struct {
+		name string
+		in   [][2]int
+		want [][2]int
+	}{
+		{"empty", nil, [][2]int{}},
+		{"single", [][2]int{{1, 2}}, [][2]int{{1, 2}}},
+		{"overlapping", [][2]int{{1, 3}, {2, 6}, {8, 10}, {15, 18}}, [][2]int{{1, 6}, {8, 10}, {15, 18}}},
+		{"unsorted", [][2]int{{8, 10}, {1, 3}, {2, 6}}, [][2]int{{1, 6}, {8, 10}}},
+		{"touching", [][2]int{{1, 3}, {3, 5}}, [][2]int{{1, 5}}},
+		{"adjacent but apart", [][2]int{{1, 2}, {3, 4}}, [][2]int{{1, 2}, {3, 4}}},
+		{"fully contained", [][2]int{{1, 10}, {2, 3}, {4, 5}}, [][2]int{{1, 10}}},
+	}
+	for _, tt := range tests {
+		if got := MergeIntervals(tt.in); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: MergeIntervals(%v) = %v, want %v", tt.name, tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestMergeIntervalsLeavesInputAlone(t *testing.T) {
+	in := [][2]int{{8, 10}, {1, 3}, {2, 6}}
+	MergeIntervals(in)
+	if want := [][2]int{{8, 10}, {1, 3}, {2, 6}}; !reflect.DeepEqual(in, want) {
+		t.Errorf("input changed to %v, want %v", in, want)
+	}
+}
+
+func TestInsertInterval(t *testing.T) {
+	tests := []struct {
+		name   string
+		sorted [][2]int
+		add    [2]int
+		want   [][2]int
+	}{
+		{"into empty", nil, [2]int{4, 8}, [][2]int{{4, 8}}},
+		{"overlapping", [][2]int{{1, 3}, {6, 9}}, [2]int{2, 5}, [][2]int{{1, 5}, {6, 9}}},
+		{"spanning", [][2]int{{1, 2}, {3, 5}, {6, 7}, {8, 10}}, [2]int{4, 8}, [][2]int{{1, 2}, {3, 10}}},
+		{"touching", [][2]int{{1, 3}, {6, 9}}, [2]int{3, 6}, [][2]int{{1, 9}}},
+		{"before all", [][2]int{{5, 6}}, [2]int{1, 2}, [][2]int{{1, 2}, {5, 6}}},
+		{"after all", [][2]int{{1, 2}}, [2]int{5, 6}, [][2]int{{1, 2}, {5, 6}}},
+		{"contained", [][2]int{{1, 10}}, [2]int{3, 4}, [][2]int{{1, 10}}},
+	}
+	for _, tt := range tests {
+		if got := InsertInterval(tt.sorted, tt.add); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: InsertInterval(%v, %v) = %v, want %v", tt.name, tt.sorted, tt.add, got, tt.want)
+		}
+	}
+}
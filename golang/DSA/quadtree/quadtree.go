@@ -0,0 +1,241 @@
+package main
+
+import "fmt"
+
+// A quadtree is a spatial index: each node covers a square-ish region and,
+// once it holds too many items, splits into four quadrants. A query only
+// descends into quadrants that overlap the search region, so it skips most
+// of the plane instead of testing every shape.
+//
+// The shapes below mirror the ones in visitor-pattern/shape_example.go, which
+// has no bounding-box operation, so BoundingBoxCalculator is a new visitor
+// introduced here. Coordinates follow SVG: y grows downwards.
+
+// ShapeVisitor defines the visitor interface
+type ShapeVisitor interface {
+	VisitCircle(c *Circle)
+	VisitRectangle(r *Rectangle)
+	VisitTriangle(t *Triangle)
+}
+
+// Shape is the element interface
+type Shape interface {
+	Accept(v ShapeVisitor)
+}
+
+// Circle represents a circle
+type Circle struct {
+	Radius float64
+	X, Y   float64 // center coordinates
+}
+
+func (c *Circle) Accept(v ShapeVisitor) {
+	v.VisitCircle(c)
+}
+
+// Rectangle represents a rectangle
+type Rectangle struct {
+	Width  float64
+	Height float64
+	X, Y   float64 // top-left corner coordinates
+}
+
+func (r *Rectangle) Accept(v ShapeVisitor) {
+	v.VisitRectangle(r)
+}
+
+// Triangle represents a triangle with its apex Height above the base
+type Triangle struct {
+	Base   float64
+	Height float64
+	X, Y   float64 // base point coordinates
+}
+
+func (t *Triangle) Accept(v ShapeVisitor) {
+	v.VisitTriangle(t)
+}
+
+// Rect is an axis-aligned rectangle given by its top-left corner and size
+type Rect struct {
+	X, Y          float64
+	Width, Height float64
+}
+
+// Intersects reports whether r and other overlap. Touching edges count.
+func (r Rect) Intersects(other Rect) bool {
+	return r.X <= other.X+other.Width && other.X <= r.X+r.Width &&
+		r.Y <= other.Y+other.Height && other.Y <= r.Y+r.Height
+}
+
+// Contains reports whether other lies entirely inside r
+func (r Rect) Contains(other Rect) bool {
+	return other.X >= r.X && other.X+other.Width <= r.X+r.Width &&
+		other.Y >= r.Y && other.Y+other.Height <= r.Y+r.Height
+}
+
+// BoundingBoxCalculator computes the bounding box of the last shape visited
+type BoundingBoxCalculator struct {
+	Box Rect
+}
+
+func (b *BoundingBoxCalculator) VisitCircle(c *Circle) {
+	b.Box = Rect{X: c.X - c.Radius, Y: c.Y - c.Radius, Width: 2 * c.Radius, Height: 2 * c.Radius}
+}
+
+func (b *BoundingBoxCalculator) VisitRectangle(r *Rectangle) {
+	b.Box = Rect{X: r.X, Y: r.Y, Width: r.Width, Height: r.Height}
+}
+
+func (b *BoundingBoxCalculator) VisitTriangle(t *Triangle) {
+	// The apex sits Height above the base, i.e. at a smaller y
+	b.Box = Rect{X: t.X, Y: t.Y - t.Height, Width: t.Base, Height: t.Height}
+}
+
+// BoundingBox returns the bounding box of shape
+func BoundingBox(shape Shape) Rect {
+	calculator := &BoundingBoxCalculator{}
+	shape.Accept(calculator)
+	return calculator.Box
+}
+
+// quadItem is an indexed shape with its cached bounding box
+type quadItem struct {
+	shape Shape
+	box   Rect
+}
+
+type quadNode struct {
+	bounds   Rect
+	depth    int
+	items    []quadItem
+	children []*quadNode // nil for a leaf, else the four quadrants
+}
+
+// Quadtree indexes shapes by bounding box. A node splits once it holds more
+// than capacity shapes, unless it is already maxDepth deep. A shape that
+// straddles a quadrant boundary stays in the node above the split.
+type Quadtree struct {
+	root     *quadNode
+	capacity int
+	maxDepth int
+	size     int
+}
+
+// NewQuadtree creates an empty quadtree covering bounds
+func NewQuadtree(bounds Rect, capacity, maxDepth int) *Quadtree {
+	return &Quadtree{
+		root:     &quadNode{bounds: bounds},
+		capacity: max(capacity, 1),
+		maxDepth: maxDepth,
+	}
+}
+
+// Insert adds shape, returning false if its bounding box is not entirely
+// inside the tree's bounds
+func (q *Quadtree) Insert(shape Shape) bool {
+	item := quadItem{shape: shape, box: BoundingBox(shape)}
+	if !q.root.bounds.Contains(item.box) {
+		return false
+	}
+	q.insert(q.root, item)
+	q.size++
+	return true
+}
+
+func (q *Quadtree) insert(node *quadNode, item quadItem) {
+	for node.children != nil {
+		child := node.childContaining(item.box)
+		if child == nil {
+			break // straddles a boundary, so it stays here
+		}
+		node = child
+	}
+	node.items = append(node.items, item)
+	if node.children == nil && len(node.items) > q.capacity && node.depth < q.maxDepth {
+		node.subdivide()
+	}
+}
+
+// childContaining returns the quadrant that wholly contains box, or nil
+func (n *quadNode) childContaining(box Rect) *quadNode {
+	for _, child := range n.children {
+		if child.bounds.Contains(box) {
+			return child
+		}
+	}
+	return nil
+}
+
+// subdivide splits a leaf into four quadrants and pushes down every item
+// that fits entirely in one of them
+func (n *quadNode) subdivide() {
+	halfWidth, halfHeight := n.bounds.Width/2, n.bounds.Height/2
+	x, y := n.bounds.X, n.bounds.Y
+	for _, corner := range [][2]float64{{x, y}, {x + halfWidth, y}, {x, y + halfHeight}, {x + halfWidth, y + halfHeight}} {
+		n.children = append(n.children, &quadNode{
+			bounds: Rect{X: corner[0], Y: corner[1], Width: halfWidth, Height: halfHeight},
+			depth:  n.depth + 1,
+		})
+	}
+
+	kept := n.items[:0]
+	for _, item := range n.items {
+		if child := n.childContaining(item.box); child != nil {
+			child.items = append(child.items, item)
+		} else {
+			kept = append(kept, item)
+		}
+	}
+	n.items = kept
+}
+
+// Query returns every shape whose bounding box overlaps region
+func (q *Quadtree) Query(region Rect) []Shape {
+	var found []Shape
+	var search func(node *quadNode)
+	search = func(node *quadNode) {
+		if !node.bounds.Intersects(region) {
+			return
+		}
+		for _, item := range node.items {
+			if item.box.Intersects(region) {
+				found = append(found, item.shape)
+			}
+		}
+		for _, child := range node.children {
+			search(child)
+		}
+	}
+	search(q.root)
+	return found
+}
+
+// Len returns the number of shapes in the tree
+func (q *Quadtree) Len() int {
+	return q.size
+}
+
+// Depth returns the depth of the deepest node
+func (q *Quadtree) Depth() int {
+	var deepest func(node *quadNode) int
+	deepest = func(node *quadNode) int {
+		d := node.depth
+		for _, child := range node.children {
+			d = max(d, deepest(child))
+		}
+		return d
+	}
+	return deepest(q.root)
+}
+
+func main() {
+	tree := NewQuadtree(Rect{Width: 100, Height: 100}, 2, 6)
+	topLeft := &Circle{Radius: 5, X: 20, Y: 20}
+	centre := &Rectangle{Width: 20, Height: 20, X: 40, Y: 40}
+	for _, shape := range []Shape{topLeft, &Circle{Radius: 5, X: 80, Y: 80}, centre} {
+		tree.Insert(shape)
+	}
+	// The centre rectangle straddles all four quadrants, so it overlaps a
+	// query of the top-left one
+	fmt.Println("Top-left quadrant:", len(tree.Query(Rect{Width: 50, Height: 50})), "shapes") // 2
+}
@@ -0,0 +1,118 @@
+package main
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+// containsAll reports whether every shape in want is in got
+func containsAll(got, want []Shape) bool {
+	for _, shape := range want {
+		if !slices.Contains(got, shape) {
+			return false
+		}
+	}
+	return true
+}
+
+func TestQuadtreeQuery(t *testing.T) {
+	tree := NewQuadtree(Rect{Width: 100, Height: 100}, 2, 6)
+
+	// One shape in each quadrant, plus one across the centre
+	topLeft := &Circle{Radius: 5, X: 20, Y: 20}
+	topRight := &Rectangle{Width: 10, Height: 10, X: 70, Y: 10}
+	bottomLeft := &Triangle{Base: 10, Height: 10, X: 10, Y: 90}
+	bottomRight := &Circle{Radius: 5, X: 80, Y: 80}
+	centre := &Rectangle{Width: 20, Height: 20, X: 40, Y: 40}
+	outside := &Circle{Radius: 10, X: 100, Y: 100}
+	for _, shape := range []Shape{topLeft, topRight, bottomLeft, bottomRight, centre} {
+		if !tree.Insert(shape) {
+			t.Fatalf("Insert(%+v) = false, want true", shape)
+		}
+	}
+	if tree.Insert(outside) {
+		t.Error("Insert of a shape outside the bounds = true, want false")
+	}
+	// Five shapes with capacity 2 must have split the root
+	if tree.Len() != 5 || tree.Depth() < 1 {
+		t.Errorf("Len(), Depth() = %d, %d, want 5 and a split root", tree.Len(), tree.Depth())
+	}
+
+	queries := []struct {
+		name   string
+		region Rect
+		want   []Shape
+	}{
+		{"top-left quadrant", Rect{Width: 50, Height: 50}, []Shape{topLeft, centre}},
+		{"bottom-right corner", Rect{X: 70, Y: 70, Width: 30, Height: 30}, []Shape{bottomRight}},
+		{"around the triangle", Rect{X: 0, Y: 75, Width: 30, Height: 25}, []Shape{bottomLeft}},
+		{"empty area", Rect{X: 5, Y: 50, Width: 20, Height: 20}, nil},
+		{"whole plane", Rect{Width: 100, Height: 100}, []Shape{topLeft, topRight, bottomLeft, bottomRight, centre}},
+	}
+	for _, tt := range queries {
+		got := tree.Query(tt.region)
+		if len(got) != len(tt.want) || !containsAll(got, tt.want) {
+			t.Errorf("%s: Query() found %d shapes, want %d", tt.name, len(got), len(tt.want))
+		}
+	}
+}
+
+func TestQuadtreeMatchesBruteForce(t *testing.T) {
+	random := rand.New(rand.NewSource(1))
+	big := NewQuadtree(Rect{Width: 1000, Height: 1000}, 4, 8)
+	var all []Shape
+	for len(all) < 2000 {
+		shape := &Circle{Radius: 1 + random.Float64()*10, X: random.Float64() * 1000, Y: random.Float64() * 1000}
+		if big.Insert(shape) {
+			all = append(all, shape)
+		}
+	}
+	if big.Depth() < 2 {
+		t.Fatalf("Depth() = %d, want the tree to have subdivided", big.Depth())
+	}
+	for i := 0; i < 200; i++ {
+		region := Rect{X: random.Float64() * 900, Y: random.Float64() * 900, Width: random.Float64() * 100, Height: random.Float64() * 100}
+		var want []Shape
+		for _, shape := range all {
+			if BoundingBox(shape).Intersects(region) {
+				want = append(want, shape)
+			}
+		}
+		if got := big.Query(region); len(got) != len(want) || !containsAll(got, want) {
+			t.Fatalf("Query(%+v) found %d shapes, brute force finds %d", region, len(got), len(want))
+		}
+	}
+}
+
+func TestQuadtreeMaxDepth(t *testing.T) {
+	// Many tiny shapes at one point keep splitting the same quadrant; the
+	// depth limit stops it
+	tree := NewQuadtree(Rect{Width: 64, Height: 64}, 1, 3)
+	for i := 0; i < 20; i++ {
+		tree.Insert(&Circle{Radius: 0.1, X: 1, Y: 1})
+	}
+	if tree.Depth() != 3 || tree.Len() != 20 {
+		t.Errorf("Depth(), Len() = %d, %d, want 3, 20", tree.Depth(), tree.Len())
+	}
+	if got := tree.Query(Rect{X: 0, Y: 0, Width: 2, Height: 2}); len(got) != 20 {
+		t.Errorf("Query() found %d shapes, want 20", len(got))
+	}
+}
+
+func TestBoundingBox(t *testing.T) {
+	tests := []struct {
+		shape Shape
+		want  Rect
+	}{
+		{&Circle{Radius: 5, X: 20, Y: 20}, Rect{X: 15, Y: 15, Width: 10, Height: 10}},
+		{&Rectangle{Width: 10, Height: 4, X: 70, Y: 10}, Rect{X: 70, Y: 10, Width: 10, Height: 4}},
+		// The apex is above the base, at a smaller y
+		{&Triangle{Base: 10, Height: 8, X: 10, Y: 90}, Rect{X: 10, Y: 82, Width: 10, Height: 8}},
+	}
+	for _, tt := range tests {
+		if got := BoundingBox(tt.shape); got != tt.want {
+			t.Errorf("BoundingBox(%+v) = %+v, want %+v", tt.shape, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"cmp"
+	"fmt"
+)
+
+// Binary search halves a sorted slice's search range on every step, finding a
+// value in O(log n). All three functions below search the half-open range
+// [lo, hi), which avoids the off-by-one mistakes of the closed-range version.
+
+// LowerBound returns the index of the first element >= target, which is
+// where target would be inserted to keep s sorted. With duplicates it lands
+// on the first occurrence; if every element is smaller it returns len(s).
+func LowerBound[T cmp.Ordered](s []T, target T) int {
+	lo, hi := 0, len(s)
+	for lo < hi {
+		mid := lo + (hi-lo)/2 // avoids overflowing lo+hi
+		if s[mid] < target {
+			lo = mid + 1
+		} else {
+			hi = mid
+		}
+	}
+	return lo
+}
+
+// UpperBound returns the index of the first element > target, i.e. just past
+// the last occurrence of target
+func UpperBound[T cmp.Ordered](s []T, target T) int {
+	lo, hi := 0, len(s)
+	for lo < hi {
+		mid := lo + (hi-lo)/2
+		if s[mid] <= target {
+			lo = mid + 1
+		} else {
+			hi = mid
+		}
+	}
+	return lo
+}
+
+// BinarySearch returns the index of target in the sorted slice s and whether
+// it was found. With duplicates the first occurrence is returned; when not
+// found the index is where target would be inserted.
+func BinarySearch[T cmp.Ordered](s []T, target T) (int, bool) {
+	i := LowerBound(s, target)
+	return i, i < len(s) && s[i] == target
+}
+
+func main() {
+	nums := []int{1, 3, 3, 3, 5, 8, 13}
+	index, found := BinarySearch(nums, 3)
+	fmt.Println("3 at", index, found, "insert 4 at", LowerBound(nums, 4)) // 1 true 4
+
+	// Any ordered type works, e.g. strings; UpperBound-LowerBound counts matches
+	words := []string{"apple", "banana", "banana", "cherry"}
+	index, found = BinarySearch(words, "banana")
+	fmt.Println("banana at", index, found, "count",
+		UpperBound(words, "banana")-LowerBound(words, "banana")) // 1 true 2
+}
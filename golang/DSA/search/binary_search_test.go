@@ -0,0 +1,52 @@
+package main
+
+import "testing"
+
+func TestBinarySearch(t *testing.T) {
+	nums := []int{1, 3, 3, 3, 5, 8, 13}
+	tests := []struct {
+		name      string
+		s         []int
+		target    int
+		wantIndex int
+		wantFound bool
+		wantLower int
+		wantUpper int
+	}{
+		{"empty", nil, 4, 0, false, 0, 0},
+		{"single found", []int{4}, 4, 0, true, 0, 1},
+		{"single not found", []int{4}, 5, 1, false, 1, 1},
+		{"first", nums, 1, 0, true, 0, 1},
+		{"last", nums, 13, 6, true, 6, 7},
+		{"duplicates", nums, 3, 1, true, 1, 4},
+		{"missing middle", nums, 4, 4, false, 4, 4},
+		{"below all", nums, 0, 0, false, 0, 0},
+		{"above all", nums, 20, 7, false, 7, 7},
+	}
+	for _, tt := range tests {
+		index, found := BinarySearch(tt.s, tt.target)
+		if index != tt.wantIndex || found != tt.wantFound {
+			t.Errorf("%s: BinarySearch(%v, %d) = %d, %v, want %d, %v",
+				tt.name, tt.s, tt.target, index, found, tt.wantIndex, tt.wantFound)
+		}
+		if got := LowerBound(tt.s, tt.target); got != tt.wantLower {
+			t.Errorf("%s: LowerBound(%v, %d) = %d, want %d", tt.name, tt.s, tt.target, got, tt.wantLower)
+		}
+		if got := UpperBound(tt.s, tt.target); got != tt.wantUpper {
+			t.Errorf("%s: UpperBound(%v, %d) = %d, want %d", tt.name, tt.s, tt.target, got, tt.wantUpper)
+		}
+	}
+}
+
+func TestBinarySearchStrings(t *testing.T) {
+	words := []string{"apple", "banana", "banana", "cherry"}
+	if index, found := BinarySearch(words, "banana"); index != 1 || !found {
+		t.Errorf("BinarySearch(banana) = %d, %v, want 1, true", index, found)
+	}
+	if count := UpperBound(words, "banana") - LowerBound(words, "banana"); count != 2 {
+		t.Errorf("banana count = %d, want 2", count)
+	}
+	if index, found := BinarySearch(words, "blueberry"); index != 3 || found {
+		t.Errorf("BinarySearch(blueberry) = %d, %v, want 3, false", index, found)
+	}
+}
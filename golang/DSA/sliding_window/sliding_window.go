@@ -0,0 +1,39 @@
+package main
+
+import "fmt"
+
+// MaxSlidingWindow returns the maximum of every window of k consecutive
+// elements, in O(n) time. It keeps a deque of indexes whose values are in
+// decreasing order: a new value first pops every smaller value off the back
+// (they can never be a maximum again), so the front is always the current
+// window's maximum and only leaves once it slides out of the window.
+//
+// An empty input or k <= 0 gives an empty result. A k larger than the input
+// is treated as one window over the whole slice.
+func MaxSlidingWindow(nums []int, k int) []int {
+	result := []int{}
+	if len(nums) == 0 || k <= 0 {
+		return result
+	}
+	k = min(k, len(nums))
+
+	deque := make([]int, 0, k) // indexes into nums, values decreasing
+	for i, value := range nums {
+		if len(deque) > 0 && deque[0] <= i-k {
+			deque = deque[1:] // the front fell out of the window
+		}
+		for len(deque) > 0 && nums[deque[len(deque)-1]] <= value {
+			deque = deque[:len(deque)-1]
+		}
+		deque = append(deque, i)
+		if i >= k-1 {
+			result = append(result, nums[deque[0]])
+		}
+	}
+	return result
+}
+
+func main() {
+	nums := []int{1, 3, -1, -3, 5, 3, 6, 7}
+	fmt.Println("MaxSlidingWindow k=3:", MaxSlidingWindow(nums, 3)) // [3 3 5 5 6 7]
+}
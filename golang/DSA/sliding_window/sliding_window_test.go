@@ -0,0 +1,57 @@
+package main
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+// maxSlidingWindowBrute is the O(n*k) reference: it rescans every window
+func maxSlidingWindowBrute(nums []int, k int) []int {
+	result := []int{}
+	if len(nums) == 0 || k <= 0 {
+		return result
+	}
+	k = min(k, len(nums))
+	for i := 0; i+k <= len(nums); i++ {
+		result = append(result, slices.Max(nums[i:i+k]))
+	}
+	return result
+}
+
+func TestMaxSlidingWindow(t *testing.T) {
+	tests := []struct {
+		nums []int
+		k    int
+		want []int
+	}{
+		{[]int{1, 3, -1, -3, 5, 3, 6, 7}, 3, []int{3, 3, 5, 5, 6, 7}},
+		{[]int{1, 3, -1}, 1, []int{1, 3, -1}},
+		{[]int{4, 2, 12, 3}, 10, []int{12}}, // k larger than the input
+		{[]int{4, 2}, 0, []int{}},
+		{[]int{4, 2}, -1, []int{}},
+		{nil, 3, []int{}},
+		{[]int{9, 8, 7, 6, 5}, 2, []int{9, 8, 7, 6}},
+		{[]int{2, 2, 2, 2}, 2, []int{2, 2, 2}},
+	}
+	for _, tt := range tests {
+		if got := MaxSlidingWindow(tt.nums, tt.k); !slices.Equal(got, tt.want) {
+			t.Errorf("MaxSlidingWindow(%v, %d) = %v, want %v", tt.nums, tt.k, got, tt.want)
+		}
+	}
+}
+
+func TestMaxSlidingWindowMatchesBruteForce(t *testing.T) {
+	random := rand.New(rand.NewSource(1))
+	for trial := 0; trial < 1000; trial++ {
+		nums := make([]int, random.Intn(50))
+		for i := range nums {
+			nums[i] = random.Intn(21) - 10 // small range so duplicates are common
+		}
+		k := random.Intn(len(nums)+3) - 1 // includes k <= 0 and k > len(nums)
+		got, want := MaxSlidingWindow(nums, k), maxSlidingWindowBrute(nums, k)
+		if !slices.Equal(got, want) {
+			t.Fatalf("MaxSlidingWindow(%v, %d) = %v, brute force gives %v", nums, k, got, want)
+		}
+	}
+}
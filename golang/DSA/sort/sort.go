@@ -0,0 +1,123 @@
+package main
+
+import (
+	"cmp"
+	"fmt"
+)
+
+// QuickSort sorts s in place in O(n log n) average time. It is not stable:
+// equal elements may end up in a different order than they started in.
+func QuickSort[T cmp.Ordered](s []T) {
+	QuickSortFunc(s, cmp.Compare[T])
+}
+
+// QuickSortFunc is QuickSort ordered by compare, which returns a negative
+// number when a < b, zero when they are equal and a positive number when a > b
+func QuickSortFunc[T any](s []T, compare func(a, b T) int) {
+	for len(s) > 1 {
+		p := hoarePartition(s, compare)
+		// Recurse into the smaller half and loop on the larger one, so the
+		// recursion depth stays O(log n) even on bad pivots
+		if p+1 < len(s)-p-1 {
+			QuickSortFunc(s[:p+1], compare)
+			s = s[p+1:]
+		} else {
+			QuickSortFunc(s[p+1:], compare)
+			s = s[:p+1]
+		}
+	}
+}
+
+// hoarePartition splits s around the median of its first, middle and last
+// elements and returns p < len(s)-1 such that every element of s[:p+1] is <=
+// every element of s[p+1:]. The median-of-three pivot keeps sorted and
+// reverse-sorted input from hitting the O(n^2) worst case, and Hoare's scheme
+// stops on elements equal to the pivot, so all-equal input splits evenly.
+func hoarePartition[T any](s []T, compare func(a, b T) int) int {
+	// Moving the pivot to the front guarantees both halves are non-empty
+	m := medianOfThree(s, 0, len(s)/2, len(s)-1, compare)
+	s[0], s[m] = s[m], s[0]
+	pivot := s[0]
+	i, j := -1, len(s)
+	for {
+		for i++; compare(s[i], pivot) < 0; i++ {
+		}
+		for j--; compare(s[j], pivot) > 0; j-- {
+		}
+		if i >= j {
+			return j
+		}
+		s[i], s[j] = s[j], s[i]
+	}
+}
+
+// medianOfThree returns whichever of the indexes a, b and c holds the median
+// of the three values
+func medianOfThree[T any](s []T, a, b, c int, compare func(a, b T) int) int {
+	if compare(s[a], s[b]) > 0 {
+		a, b = b, a
+	}
+	if compare(s[b], s[c]) > 0 {
+		b = c
+		if compare(s[a], s[b]) > 0 {
+			b = a
+		}
+	}
+	return b
+}
+
+// MergeSort sorts s in O(n log n) time using O(n) extra space. It is stable:
+// equal elements keep their original relative order.
+func MergeSort[T cmp.Ordered](s []T) {
+	MergeSortFunc(s, cmp.Compare[T])
+}
+
+// MergeSortFunc is MergeSort ordered by compare
+func MergeSortFunc[T any](s []T, compare func(a, b T) int) {
+	buffer := make([]T, len(s))
+	mergeSort(s, buffer, compare)
+}
+
+func mergeSort[T any](s, buffer []T, compare func(a, b T) int) {
+	if len(s) < 2 {
+		return
+	}
+	mid := len(s) / 2
+	mergeSort(s[:mid], buffer[:mid], compare)
+	mergeSort(s[mid:], buffer[mid:], compare)
+
+	copy(buffer, s)
+	left, right := buffer[:mid], buffer[mid:len(s)]
+	i, j, k := 0, 0, 0
+	for i < len(left) && j < len(right) {
+		// Taking from the left on ties is what makes the sort stable
+		if compare(right[j], left[i]) < 0 {
+			s[k] = right[j]
+			j++
+		} else {
+			s[k] = left[i]
+			i++
+		}
+		k++
+	}
+	k += copy(s[k:], left[i:])
+	copy(s[k:], right[j:])
+}
+
+func main() {
+	nums := []int{5, 2, 9, 1, 5, 6}
+	QuickSort(nums)
+	fmt.Println("QuickSort:", nums) // [1 2 5 5 6 9]
+
+	// MergeSort is stable: sorting by age only keeps people of the same age
+	// in their original order
+	type person struct {
+		name string
+		age  int
+	}
+	people := []person{{"Alice", 30}, {"Bob", 25}, {"Carol", 30}, {"Dave", 25}}
+	MergeSortFunc(people, func(a, b person) int { return cmp.Compare(a.age, b.age) })
+	fmt.Println("MergeSort by age:", people) // [{Bob 25} {Dave 25} {Alice 30} {Carol 30}]
+
+	// `go test -bench .` compares both with slices.Sort
+}
@@ -0,0 +1,121 @@
+package main
+
+import (
+	"cmp"
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+// sortAlgorithms lists the int sorts under test
+var sortAlgorithms = []struct {
+	name   string
+	sortFn func([]int)
+}{
+	{"QuickSort", QuickSort[int]},
+	{"MergeSort", MergeSort[int]},
+}
+
+func TestSorts(t *testing.T) {
+	random := rand.New(rand.NewSource(42)).Perm(1000)
+	sorted := make([]int, 1000)
+	reversed := make([]int, 1000)
+	for i := range sorted {
+		sorted[i] = i
+		reversed[i] = len(reversed) - i
+	}
+
+	inputs := []struct {
+		name string
+		s    []int
+	}{
+		{"empty", nil},
+		{"single", []int{1}},
+		{"two reversed", []int{2, 1}},
+		{"random", random},
+		{"sorted", sorted},
+		{"reverse-sorted", reversed},
+		{"all-equal", slices.Repeat([]int{7}, 1000)},
+		{"few distinct", []int{2, 1, 2, 0, 1, 2, 0, 0, 1}},
+		{"negatives", []int{3, -1, 0, -7, 2, -1}},
+	}
+	for _, in := range inputs {
+		want := slices.Clone(in.s)
+		slices.Sort(want)
+		for _, alg := range sortAlgorithms {
+			got := slices.Clone(in.s)
+			alg.sortFn(got)
+			if !slices.Equal(got, want) {
+				t.Errorf("%s(%s) is not the sorted input", alg.name, in.name)
+			}
+		}
+	}
+}
+
+func TestSortsRandomInputs(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for trial := 0; trial < 200; trial++ {
+		in := make([]int, rng.Intn(100))
+		for i := range in {
+			in[i] = rng.Intn(20) // plenty of duplicates
+		}
+		want := slices.Clone(in)
+		slices.Sort(want)
+		for _, alg := range sortAlgorithms {
+			got := slices.Clone(in)
+			alg.sortFn(got)
+			if !slices.Equal(got, want) {
+				t.Fatalf("%s(%v) = %v, want %v", alg.name, in, got, want)
+			}
+		}
+	}
+}
+
+func TestSortStrings(t *testing.T) {
+	want := []string{"apple", "banana", "cherry", "date"}
+	for _, sortFn := range []func([]string){QuickSort[string], MergeSort[string]} {
+		got := []string{"date", "banana", "apple", "cherry"}
+		sortFn(got)
+		if !slices.Equal(got, want) {
+			t.Errorf("sorted strings = %v, want %v", got, want)
+		}
+	}
+}
+
+func TestMergeSortIsStable(t *testing.T) {
+	type person struct {
+		name string
+		age  int
+	}
+	// Names are alphabetical, so a stable sort by age keeps each age group
+	// alphabetical too
+	people := []person{
+		{"Alice", 30}, {"Bob", 25}, {"Carol", 30}, {"Dave", 25},
+		{"Erin", 30}, {"Frank", 25}, {"Grace", 30}, {"Heidi", 25},
+	}
+	MergeSortFunc(people, func(a, b person) int { return cmp.Compare(a.age, b.age) })
+
+	want := []person{
+		{"Bob", 25}, {"Dave", 25}, {"Frank", 25}, {"Heidi", 25},
+		{"Alice", 30}, {"Carol", 30}, {"Erin", 30}, {"Grace", 30},
+	}
+	if !slices.Equal(people, want) {
+		t.Errorf("MergeSortFunc by age = %v, want %v", people, want)
+	}
+}
+
+const benchSize = 10000
+
+var benchInput = rand.New(rand.NewSource(1)).Perm(benchSize)
+
+func benchmarkSort(b *testing.B, sortFn func([]int)) {
+	s := make([]int, benchSize)
+	for i := 0; i < b.N; i++ {
+		copy(s, benchInput)
+		sortFn(s)
+	}
+}
+
+func BenchmarkQuickSort(b *testing.B)  { benchmarkSort(b, QuickSort[int]) }
+func BenchmarkMergeSort(b *testing.B)  { benchmarkSort(b, MergeSort[int]) }
+func BenchmarkSlicesSort(b *testing.B) { benchmarkSort(b, slices.Sort[[]int]) }
@@ -0,0 +1,103 @@
+package main
+
+import "fmt"
+
+// Stack is a last-in, first-out container backed by a slice
+type Stack[T any] struct {
+	items []T
+}
+
+// Push adds an item to the top of the stack
+func (s *Stack[T]) Push(item T) {
+	s.items = append(s.items, item)
+}
+
+// Pop removes and returns the top item, or false if the stack is empty
+func (s *Stack[T]) Pop() (T, bool) {
+	var zero T
+	if len(s.items) == 0 {
+		return zero, false
+	}
+	item := s.items[len(s.items)-1]
+	s.items[len(s.items)-1] = zero // drop the reference so it can be collected
+	s.items = s.items[:len(s.items)-1]
+	return item, true
+}
+
+// Peek returns the top item without removing it, or false if the stack is empty
+func (s *Stack[T]) Peek() (T, bool) {
+	if len(s.items) == 0 {
+		var zero T
+		return zero, false
+	}
+	return s.items[len(s.items)-1], true
+}
+
+func (s *Stack[T]) Len() int { return len(s.items) }
+
+func (s *Stack[T]) IsEmpty() bool { return len(s.items) == 0 }
+
+// Queue is a first-in, first-out container backed by a slice
+type Queue[T any] struct {
+	items []T
+}
+
+// Enqueue adds an item to the back of the queue
+func (q *Queue[T]) Enqueue(item T) {
+	q.items = append(q.items, item)
+}
+
+// Dequeue removes and returns the front item, or false if the queue is empty
+func (q *Queue[T]) Dequeue() (T, bool) {
+	var zero T
+	if len(q.items) == 0 {
+		return zero, false
+	}
+	item := q.items[0]
+	q.items[0] = zero
+	q.items = q.items[1:]
+	return item, true
+}
+
+// Front returns the front item without removing it, or false if the queue is empty
+func (q *Queue[T]) Front() (T, bool) {
+	if len(q.items) == 0 {
+		var zero T
+		return zero, false
+	}
+	return q.items[0], true
+}
+
+func (q *Queue[T]) Len() int { return len(q.items) }
+
+func (q *Queue[T]) IsEmpty() bool { return len(q.items) == 0 }
+
+func main() {
+	var stack Stack[string]
+	_, ok := stack.Pop()
+	fmt.Println("pop on empty stack ok:", ok) // false
+	for _, s := range []string{"a", "b", "c"} {
+		stack.Push(s)
+	}
+	top, _ := stack.Peek()
+	fmt.Println("stack peek:", top, "len:", stack.Len())
+	for !stack.IsEmpty() {
+		item, _ := stack.Pop()
+		fmt.Print(item, " ") // c b a
+	}
+	fmt.Println()
+
+	var queue Queue[int]
+	_, ok = queue.Dequeue()
+	fmt.Println("dequeue on empty queue ok:", ok) // false
+	for i := 1; i <= 3; i++ {
+		queue.Enqueue(i)
+	}
+	front, _ := queue.Front()
+	fmt.Println("queue front:", front, "len:", queue.Len())
+	for !queue.IsEmpty() {
+		item, _ := queue.Dequeue()
+		fmt.Print(item, " ") // 1 2 3
+	}
+	fmt.Println()
+}
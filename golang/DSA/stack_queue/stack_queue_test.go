@@ -0,0 +1,78 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestStackEmpty(t *testing.T) {
+	var s Stack[string]
+	if !s.IsEmpty() || s.Len() != 0 {
+		t.Errorf("new stack: IsEmpty = %v, Len = %d, want true, 0", s.IsEmpty(), s.Len())
+	}
+	if item, ok := s.Pop(); ok || item != "" {
+		t.Errorf("Pop on empty stack = %q, %v, want \"\", false", item, ok)
+	}
+	if item, ok := s.Peek(); ok || item != "" {
+		t.Errorf("Peek on empty stack = %q, %v, want \"\", false", item, ok)
+	}
+}
+
+func TestStackOrder(t *testing.T) {
+	var s Stack[int]
+	for i := 1; i <= 3; i++ {
+		s.Push(i)
+	}
+	if top, ok := s.Peek(); !ok || top != 3 {
+		t.Errorf("Peek = %d, %v, want 3, true", top, ok)
+	}
+	if s.Len() != 3 {
+		t.Errorf("Len after Peek = %d, want 3", s.Len())
+	}
+
+	var got []int
+	for !s.IsEmpty() {
+		item, _ := s.Pop()
+		got = append(got, item)
+	}
+	if want := []int{3, 2, 1}; !reflect.DeepEqual(got, want) {
+		t.Errorf("popped %v, want %v", got, want)
+	}
+	if _, ok := s.Pop(); ok {
+		t.Error("Pop after draining the stack returned ok")
+	}
+}
+
+func TestQueueEmpty(t *testing.T) {
+	var q Queue[int]
+	if !q.IsEmpty() || q.Len() != 0 {
+		t.Errorf("new queue: IsEmpty = %v, Len = %d, want true, 0", q.IsEmpty(), q.Len())
+	}
+	if item, ok := q.Dequeue(); ok || item != 0 {
+		t.Errorf("Dequeue on empty queue = %d, %v, want 0, false", item, ok)
+	}
+	if item, ok := q.Front(); ok || item != 0 {
+		t.Errorf("Front on empty queue = %d, %v, want 0, false", item, ok)
+	}
+}
+
+func TestQueueOrder(t *testing.T) {
+	var q Queue[string]
+	for _, s := range []string{"a", "b", "c"} {
+		q.Enqueue(s)
+	}
+	if front, ok := q.Front(); !ok || front != "a" {
+		t.Errorf("Front = %q, %v, want \"a\", true", front, ok)
+	}
+
+	first, _ := q.Dequeue()
+	q.Enqueue("d") // enqueueing after a dequeue keeps FIFO order
+	got := []string{first}
+	for !q.IsEmpty() {
+		item, _ := q.Dequeue()
+		got = append(got, item)
+	}
+	if want := []string{"a", "b", "c", "d"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("dequeued %v, want %v", got, want)
+	}
+}
@@ -5,23 +5,28 @@ import (
 	"os"
 )
 
-func main() {
-
-	file, err := os.OpenFile("example.json", os.O_APPEND, 0644)
-
+// AppendToFile appends text to the file at path, creating it if needed.
+// O_APPEND alone opens the file read-only and fails if it doesn't exist,
+// so write access and creation have to be requested explicitly.
+func AppendToFile(path, text string) error {
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
 	if err != nil {
-		fmt.Println("Could not open example.txt")
-		return
+		return fmt.Errorf("could not open %s: %w", path, err)
 	}
 
-	defer file.Close()
-
-	_, err2 := file.Write([]byte("Appending some text to example.txt"))
+	if _, err := file.WriteString(text); err != nil {
+		file.Close()
+		return fmt.Errorf("could not write text to %s: %w", path, err)
+	}
+	return file.Close()
+}
 
-	if err2 != nil {
-		fmt.Println("Could not write text to example.txt")
+func main() {
+	filePath := "example.json"
 
-	} else {
-		fmt.Println("Operation successful! Text has been appended to example.txt")
+	if err := AppendToFile(filePath, "Appending some text to "+filePath); err != nil {
+		fmt.Println(err)
+		return
 	}
+	fmt.Println("Operation successful! Text has been appended to", filePath)
 }
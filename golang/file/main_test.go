@@ -0,0 +1,33 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestAppendToFileTwice(t *testing.T) {
+	// The file doesn't exist yet, so the first append has to create it
+	path := filepath.Join(t.TempDir(), "notes.txt")
+	if err := AppendToFile(path, "hello, "); err != nil {
+		t.Fatalf("first AppendToFile: %v", err)
+	}
+	if err := AppendToFile(path, "world"); err != nil {
+		t.Fatalf("second AppendToFile: %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := string(data), "hello, world"; got != want {
+		t.Errorf("file contents = %q, want %q", got, want)
+	}
+}
+
+func TestAppendToFileMissingDir(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "missing", "notes.txt")
+	if err := AppendToFile(path, "text"); err == nil {
+		t.Error("AppendToFile into a missing directory succeeded, want an error")
+	}
+}
@@ -3,39 +3,84 @@ package main
 import (
 	"bufio"
 	"fmt"
-	"log"
-	"os"	
+	"os"
 )
 
+// Line is a single line of a file together with its 1-based line number
+type Line struct {
+	Num  int
+	Text string
+}
+
 func main() {
 	filePath := "file/example.txt"
-	channel := make(chan string)
 
-	go readFileLineByLine(channel, filePath)
-	processLines(channel)
+	lines, errs := readFileLineByLine(filePath)
+	if err := processLines(lines, errs); err != nil {
+		fmt.Println("Error:", err)
+	}
+
+	// A missing file is reported instead of killing the program
+	if _, err := ReadLines("file/missing.txt"); err != nil {
+		fmt.Println("Error:", err)
+	}
+}
+
+// readFileLineByLine streams the lines of filePath on the returned line
+// channel. Once the lines channel is closed, the error channel yields the
+// error that stopped reading (nil on success) and is then closed.
+func readFileLineByLine(filePath string) (<-chan Line, <-chan error) {
+	lines := make(chan Line)
+	errs := make(chan error, 1)
+
+	go func() {
+		defer close(errs)
+		defer close(lines)
+
+		file, err := os.Open(filePath)
+		if err != nil {
+			errs <- err
+			return
+		}
+		defer file.Close()
+
+		scanner := bufio.NewScanner(file)
+
+		num := 0
+		for scanner.Scan() {
+			num++
+			lines <- Line{Num: num, Text: scanner.Text()}
+		}
+		if err := scanner.Err(); err != nil {
+			errs <- fmt.Errorf("read %s line %d: %w", filePath, num+1, err)
+		}
+	}()
+
+	return lines, errs
 }
 
+func processLines(lines <-chan Line, errs <-chan error) error {
+	for line := range lines {
+		fmt.Printf("%d: %s\n", line.Num, line.Text)
+	}
+	return <-errs
+}
 
-func readFileLineByLine(channel chan string, filePath string) {
-	file, err := os.Open(filePath)
+// ReadLines reads the whole file at path and returns its lines
+func ReadLines(path string) ([]string, error) {
+	file, err := os.Open(path)
 	if err != nil {
-		log.Fatal(err)
+		return nil, err
 	}
 	defer file.Close()
 
+	var lines []string
 	scanner := bufio.NewScanner(file)
-
 	for scanner.Scan() {
-		channel <- scanner.Text()
+		lines = append(lines, scanner.Text())
 	}
-	close(channel)
-}
-
-
-func processLines(channel chan string) {
-	for line := range channel {
-		fmt.Println(line)
+	if err := scanner.Err(); err != nil {
+		return nil, err
 	}
+	return lines, nil
 }
-
-
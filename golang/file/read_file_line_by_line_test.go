@@ -0,0 +1,63 @@
+package main
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// writeTemp writes content to a new file in a test temp dir
+func writeTemp(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "lines.txt")
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestReadLines(t *testing.T) {
+	path := writeTemp(t, "first\nsecond\n\nfourth")
+	got, err := ReadLines(path)
+	if err != nil {
+		t.Fatalf("ReadLines: %v", err)
+	}
+	if want := []string{"first", "second", "", "fourth"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("ReadLines = %q, want %q", got, want)
+	}
+}
+
+func TestReadLinesMissingFile(t *testing.T) {
+	_, err := ReadLines(filepath.Join(t.TempDir(), "missing.txt"))
+	if !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("ReadLines error = %v, want fs.ErrNotExist", err)
+	}
+}
+
+func TestReadFileLineByLineNumbersLines(t *testing.T) {
+	lines, errs := readFileLineByLine(writeTemp(t, "a\nb\nc\n"))
+	var got []Line
+	for line := range lines {
+		got = append(got, line)
+	}
+	if err := <-errs; err != nil {
+		t.Fatalf("error channel yielded %v, want nil", err)
+	}
+	want := []Line{{1, "a"}, {2, "b"}, {3, "c"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("lines = %v, want %v", got, want)
+	}
+}
+
+func TestReadFileLineByLineMissingFile(t *testing.T) {
+	lines, errs := readFileLineByLine(filepath.Join(t.TempDir(), "missing.txt"))
+	for line := range lines {
+		t.Errorf("unexpected line %v", line)
+	}
+	if err := <-errs; !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("error channel yielded %v, want fs.ErrNotExist", err)
+	}
+}
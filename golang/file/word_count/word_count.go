@@ -0,0 +1,131 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+// fileCount is one worker's result for one file
+type fileCount struct {
+	path   string
+	counts map[string]int
+	err    error
+}
+
+// CountWords counts the words in every file in paths, reading at most workers
+// files at once (fan-out) and merging the per-file counts into one map
+// (fan-in). Words are lower-cased runs of letters and digits. Files that
+// can't be read are skipped and their errors joined into the returned error,
+// so the map still holds the counts of every readable file.
+func CountWords(paths []string, workers int) (map[string]int, error) {
+	workers = max(1, min(workers, len(paths)))
+	jobs := make(chan string)
+	results := make(chan fileCount)
+
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for path := range jobs {
+				counts, err := countFileWords(path)
+				results <- fileCount{path: path, counts: counts, err: err}
+			}
+		}()
+	}
+
+	go func() {
+		for _, path := range paths {
+			jobs <- path
+		}
+		close(jobs)
+	}()
+
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+
+	total := make(map[string]int)
+	var errs []error
+	for result := range results {
+		if result.err != nil {
+			errs = append(errs, result.err)
+			continue
+		}
+		for word, n := range result.counts {
+			total[word] += n
+		}
+	}
+	return total, errors.Join(errs...)
+}
+
+// countFileWords counts the words in a single file, line by line
+func countFileWords(path string) (map[string]int, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	counts := make(map[string]int)
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		for _, word := range tokenize(scanner.Text()) {
+			counts[word]++
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err // already names the file
+	}
+	return counts, nil
+}
+
+// tokenize splits line into lower-case words, treating anything other than a
+// letter or digit as a separator
+func tokenize(line string) []string {
+	return strings.FieldsFunc(strings.ToLower(line), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+func main() {
+	dir, err := os.MkdirTemp("", "word_count")
+	if err != nil {
+		fmt.Println("Error:", err)
+		return
+	}
+	defer os.RemoveAll(dir)
+
+	files := map[string]string{
+		"a.txt": "the quick brown fox\njumps over the lazy dog\n",
+		"b.txt": "The dog barks.\nThe fox runs!\n",
+		"c.txt": "",
+		"d.txt": "quick, quick, QUICK",
+	}
+	var paths []string
+	for name, text := range files {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(text), 0644); err != nil {
+			fmt.Println("Error:", err)
+			return
+		}
+		paths = append(paths, path)
+	}
+	// One missing file and a directory, neither of which can be read
+	paths = append(paths, filepath.Join(dir, "missing.txt"), dir)
+
+	counts, err := CountWords(paths, 3)
+	fmt.Println("Counts:", counts) // the:4 quick:4 fox:2 dog:2 ...
+	fmt.Println("Aggregated error:")
+	fmt.Println(err)
+
+	counts, err = CountWords(nil, 4)
+	fmt.Println("No files:", counts, err)
+}
@@ -0,0 +1,91 @@
+package main
+
+import (
+	"errors"
+	"io/fs"
+	"maps"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// writeFiles writes each name -> text pair into dir and returns the paths
+func writeFiles(t *testing.T, dir string, files map[string]string) []string {
+	t.Helper()
+	var paths []string
+	for name, text := range files {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(text), 0644); err != nil {
+			t.Fatal(err)
+		}
+		paths = append(paths, path)
+	}
+	return paths
+}
+
+func TestCountWords(t *testing.T) {
+	paths := writeFiles(t, t.TempDir(), map[string]string{
+		"a.txt": "the quick brown fox\njumps over the lazy dog\n",
+		"b.txt": "The dog barks.\nThe fox runs!\n",
+		"c.txt": "",
+		"d.txt": "quick, quick, QUICK",
+	})
+	want := map[string]int{
+		"the": 4, "quick": 4, "brown": 1, "fox": 2, "jumps": 1, "over": 1,
+		"lazy": 1, "dog": 2, "barks": 1, "runs": 1,
+	}
+	// The result mustn't depend on how many workers share the files
+	for _, workers := range []int{-1, 0, 1, 3, 10} {
+		counts, err := CountWords(paths, workers)
+		if err != nil {
+			t.Errorf("workers %d: err = %v, want nil", workers, err)
+		}
+		if !maps.Equal(counts, want) {
+			t.Errorf("workers %d: CountWords() = %v, want %v", workers, counts, want)
+		}
+	}
+}
+
+func TestCountWordsUnreadableFiles(t *testing.T) {
+	dir := t.TempDir()
+	paths := writeFiles(t, dir, map[string]string{"a.txt": "one two two"})
+	missing := filepath.Join(dir, "missing.txt")
+	paths = append(paths, missing, dir) // a directory can't be scanned either
+
+	counts, err := CountWords(paths, 2)
+	if want := map[string]int{"one": 1, "two": 2}; !maps.Equal(counts, want) {
+		t.Errorf("CountWords() = %v, want the readable file's counts %v", counts, want)
+	}
+	if !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("err = %v, want it to wrap fs.ErrNotExist", err)
+	}
+	if err == nil || !strings.Contains(err.Error(), missing) || strings.Count(err.Error(), "\n") != 1 {
+		t.Errorf("err = %q, want one line per unreadable file", err)
+	}
+}
+
+func TestCountWordsNoFiles(t *testing.T) {
+	counts, err := CountWords(nil, 4)
+	if err != nil || !reflect.DeepEqual(counts, map[string]int{}) {
+		t.Errorf("CountWords(nil) = %v, %v, want an empty map and nil", counts, err)
+	}
+}
+
+func TestTokenize(t *testing.T) {
+	tests := []struct {
+		line string
+		want []string
+	}{
+		{"The quick, brown fox!", []string{"the", "quick", "brown", "fox"}},
+		{"it's 42 o'clock", []string{"it", "s", "42", "o", "clock"}},
+		{"Ünïcode wörds", []string{"ünïcode", "wörds"}},
+		{"  ...  ", []string{}},
+	}
+	for _, tt := range tests {
+		if got := tokenize(tt.line); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("tokenize(%q) = %q, want %q", tt.line, got, tt.want)
+		}
+	}
+}
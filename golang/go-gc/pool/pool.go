@@ -0,0 +1,66 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"sync"
+)
+
+// Pooling reuses short-lived objects instead of allocating a new one each
+// time. Fewer heap allocations means less work for the garbage collector,
+// which matters most for large buffers that escape to the heap. sync.Pool
+// may drop pooled objects at any GC, so it is a cache, not a free list.
+
+// Pool is a typed wrapper around sync.Pool
+type Pool[T any] struct {
+	pool  sync.Pool
+	reset func(*T)
+}
+
+// NewPool creates a pool that calls newFn when it is empty. If reset is not
+// nil it is applied to every value handed back through Put, so Get never
+// returns leftover state.
+func NewPool[T any](newFn func() T, reset func(*T)) *Pool[T] {
+	return &Pool[T]{
+		pool:  sync.Pool{New: func() any { return newFn() }},
+		reset: reset,
+	}
+}
+
+// Get returns a value from the pool, creating one if the pool is empty
+func (p *Pool[T]) Get() T {
+	return p.pool.Get().(T)
+}
+
+// Put returns v to the pool for reuse. v must not be used afterwards.
+func (p *Pool[T]) Put(v T) {
+	if p.reset != nil {
+		p.reset(&v)
+	}
+	p.pool.Put(v)
+}
+
+const bufferSize = 64 << 10
+
+var bufferPool = NewPool(
+	func() *bytes.Buffer { return bytes.NewBuffer(make([]byte, 0, bufferSize)) },
+	func(b **bytes.Buffer) { (*b).Reset() },
+)
+
+func main() {
+	buf := bufferPool.Get()
+	buf.WriteString("left over")
+	bufferPool.Put(buf)
+	// Put reset the buffer, so whatever Get returns next starts empty
+	fmt.Printf("Buffer after Put/Get: %q\n", bufferPool.Get().String())
+
+	// Pools work for value types too, though storing a non-pointer in
+	// sync.Pool allocates to box it in an interface
+	counts := NewPool(func() [4]int { return [4]int{} }, func(a *[4]int) { *a = [4]int{} })
+	a := counts.Get()
+	a[0] = 7
+	counts.Put(a)
+	fmt.Println("Array after Put/Get:", counts.Get())
+
+	// `go test -bench . -benchmem` compares pooled and fresh buffers
+}
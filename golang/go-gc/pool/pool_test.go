@@ -0,0 +1,64 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+)
+
+var (
+	sink       int
+	lastBuffer *bytes.Buffer // keeping a reference makes fresh buffers escape to the heap, as real ones would
+)
+
+func TestPoolResetsOnPut(t *testing.T) {
+	buf := bufferPool.Get()
+	buf.WriteString("left over")
+	bufferPool.Put(buf)
+	// Put reset the buffer, so whatever Get returns starts empty
+	if got := bufferPool.Get(); got.Len() != 0 || got.Cap() < bufferSize {
+		t.Errorf("Get() after Put = %q with cap %d, want empty with cap >= %d", got.String(), got.Cap(), bufferSize)
+	}
+
+	counts := NewPool(func() [4]int { return [4]int{} }, func(a *[4]int) { *a = [4]int{} })
+	a := counts.Get()
+	a[0] = 7
+	counts.Put(a)
+	if got := counts.Get(); got != [4]int{} {
+		t.Errorf("array Get() after Put = %v, want zeros", got)
+	}
+}
+
+func TestPoolWithoutReset(t *testing.T) {
+	created := 0
+	pool := NewPool(func() []int { created++; return make([]int, 0, 8) }, nil)
+	if got := pool.Get(); len(got) != 0 || cap(got) != 8 || created != 1 {
+		t.Errorf("Get() on an empty pool = len %d cap %d after %d creations, want len 0 cap 8 after 1",
+			len(got), cap(got), created)
+	}
+}
+
+// fill writes a full buffer's worth of data and returns its length
+func fill(buf *bytes.Buffer) int {
+	for buf.Len() < bufferSize {
+		buf.WriteString("0123456789abcdef")
+	}
+	return buf.Len()
+}
+
+func BenchmarkFreshBuffer(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		buf := bytes.NewBuffer(make([]byte, 0, bufferSize))
+		sink += fill(buf)
+		lastBuffer = buf
+	}
+}
+
+func BenchmarkPooledBuffer(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		buf := bufferPool.Get()
+		sink += fill(buf)
+		bufferPool.Put(buf)
+	}
+}
@@ -245,6 +245,19 @@ func escapeAnalysisDemo() {
 	fmt.Printf("Slice escape: %v (heap allocated, ptr: %p)\n", *slicePtr, slicePtr)
 }
 
+// MeasureAlloc returns the number of heap bytes allocated while running fn.
+// A GC runs first so earlier garbage doesn't blur the reading, and the
+// cumulative TotalAlloc counter is used so a collection during fn can't
+// make the result go negative.
+func MeasureAlloc(fn func()) uint64 {
+	var before, after runtime.MemStats
+	runtime.GC()
+	runtime.ReadMemStats(&before)
+	fn()
+	runtime.ReadMemStats(&after)
+	return after.TotalAlloc - before.TotalAlloc
+}
+
 // Example 15: Memory usage demonstration
 func memoryUsageDemo() {
 	fmt.Printf("\n=== Memory Usage Demo ===\n")
@@ -277,6 +290,35 @@ func memoryUsageDemo() {
 	fmt.Printf("  Heap Alloc: %d bytes\n", m.HeapAlloc)
 	fmt.Printf("  Heap Sys: %d bytes\n", m.HeapSys)
 	fmt.Printf("  Heap Objects: %d\n", m.HeapObjects)
+
+	// Quantify a single code path instead of reading raw stats by hand
+	var sink []byte
+	allocated := MeasureAlloc(func() {
+		sink = make([]byte, 1<<20)
+	})
+	fmt.Printf("\nAllocating a 1 MB slice cost %d heap bytes (len=%d)\n", allocated, len(sink))
+}
+
+// Example 16: Escape analysis benchmarks
+//
+// The two functions below do the same work; only the escaping one returns a
+// pointer, which forces the compiler to move the value to the heap. Compare
+// allocs/op with `go test -bench . -benchmem` (BenchmarkStackAlloc and
+// BenchmarkHeapAlloc live in the _test.go file) or confirm the compiler's
+// decision with: go build -gcflags=-m stack_vs_heap_example.go
+
+type benchPoint struct {
+	X, Y, Z int
+}
+
+//go:noinline
+func newPointValue(i int) benchPoint {
+	return benchPoint{X: i, Y: i * 2, Z: i * 3}
+}
+
+//go:noinline
+func newPointPointer(i int) *benchPoint {
+	return &benchPoint{X: i, Y: i * 2, Z: i * 3} // escapes to heap
 }
 
 func main() {
@@ -0,0 +1,43 @@
+package main
+
+import "testing"
+
+var measureSink []byte
+
+func TestMeasureAllocOneMegabyte(t *testing.T) {
+	const size = 1 << 20
+	allocated := MeasureAlloc(func() {
+		measureSink = make([]byte, size)
+	})
+	// Allow some slack for size-class rounding and runtime bookkeeping
+	if allocated < size || allocated > size+size/10 {
+		t.Errorf("MeasureAlloc reported %d bytes for a %d byte slice, want roughly %d", allocated, size, size)
+	}
+}
+
+func TestMeasureAllocNothing(t *testing.T) {
+	if allocated := MeasureAlloc(func() {}); allocated > 1024 {
+		t.Errorf("MeasureAlloc of an empty func reported %d bytes, want close to 0", allocated)
+	}
+}
+
+var (
+	benchValueSink   benchPoint
+	benchPointerSink *benchPoint
+)
+
+// BenchmarkStackAlloc returns the struct by value, so nothing escapes
+func BenchmarkStackAlloc(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		benchValueSink = newPointValue(i)
+	}
+}
+
+// BenchmarkHeapAlloc returns a pointer, so every call allocates
+func BenchmarkHeapAlloc(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		benchPointerSink = newPointPointer(i)
+	}
+}
@@ -25,6 +25,21 @@ func demonstrateUnbufferedChannel(wg *sync.WaitGroup) {
 	}()
 }
 
+// DrainChannel receives from ch until it is closed and returns every value
+// received. Values still buffered when the channel was closed are returned
+// first; the zero values a closed channel yields afterwards are not. It
+// blocks until ch is closed, so only call it on a channel someone will close.
+func DrainChannel[T any](ch <-chan T) []T {
+	var values []T
+	for {
+		val, ok := <-ch
+		if !ok {
+			return values
+		}
+		values = append(values, val)
+	}
+}
+
 // demonstrateBufferedChannel shows behavior of closed buffered channels
 func demonstrateBufferedChannel(wg *sync.WaitGroup) {
 	fmt.Println("\n=== Buffered Channel Example ===")
@@ -40,14 +55,16 @@ func demonstrateBufferedChannel(wg *sync.WaitGroup) {
 	wg.Add(1)
 	go func() {
 		defer wg.Done()
-		// Read all values from buffer
-		for i := 0; i < 3; i++ {
-			val, ok := <-ch
-			if !ok {
-				fmt.Println("Buffered channel is closed")
-			}
-			fmt.Printf("Read #%d: Value=%d, Channel Open=%v\n", i+1, val, ok)
+		// Read all values from buffer; the ok flag tells real values apart
+		// from the zero value returned once the buffer is empty
+		values := DrainChannel(ch)
+		fmt.Printf("Drained buffered values: %v\n", values)
+
+		val, ok := <-ch
+		if !ok {
+			fmt.Println("Buffered channel is closed")
 		}
+		fmt.Printf("Read after drain: Value=%d, Channel Open=%v\n", val, ok)
 	}()
 }
 
@@ -0,0 +1,48 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDrainChannel(t *testing.T) {
+	tests := []struct {
+		name     string
+		capacity int
+		values   []int
+	}{
+		{"empty buffer", 3, nil},
+		{"partially filled", 3, []int{7}},
+		{"full buffer", 3, []int{1, 2, 3}},
+		{"zero values are kept", 2, []int{0, 0}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ch := make(chan int, tt.capacity)
+			for _, v := range tt.values {
+				ch <- v
+			}
+			close(ch)
+
+			if got := DrainChannel(ch); !reflect.DeepEqual(got, tt.values) {
+				t.Errorf("DrainChannel = %v, want %v", got, tt.values)
+			}
+			// Draining a closed, empty channel returns immediately
+			if got := DrainChannel(ch); got != nil {
+				t.Errorf("second DrainChannel = %v, want nil", got)
+			}
+		})
+	}
+}
+
+func TestDrainChannelWaitsForClose(t *testing.T) {
+	ch := make(chan string)
+	go func() {
+		ch <- "a"
+		ch <- "b"
+		close(ch)
+	}()
+	if got, want := DrainChannel(ch), []string{"a", "b"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("DrainChannel = %v, want %v", got, want)
+	}
+}
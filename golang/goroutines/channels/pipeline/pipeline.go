@@ -0,0 +1,57 @@
+package main
+
+import (
+	"context"
+	"fmt"
+)
+
+// Generator sends nums on the returned channel, then closes it. It stops
+// early and closes the channel if ctx is cancelled.
+func Generator(ctx context.Context, nums ...int) <-chan int {
+	out := make(chan int)
+	go func() {
+		defer close(out)
+		for _, n := range nums {
+			select {
+			case out <- n:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return out
+}
+
+// Square sends the square of every value from in, closing its output when in
+// is closed or ctx is cancelled. Every send selects on ctx.Done() so the stage
+// can't block forever on a consumer that has stopped reading.
+func Square(ctx context.Context, in <-chan int) <-chan int {
+	out := make(chan int)
+	go func() {
+		defer close(out)
+		for n := range in {
+			select {
+			case out <- n * n:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return out
+}
+
+func main() {
+	fmt.Println("=== Full pipeline ===")
+	for v := range Square(context.Background(), Generator(context.Background(), 1, 2, 3, 4)) {
+		fmt.Print(v, " ")
+	}
+	fmt.Println()
+
+	fmt.Println("=== Cancelled mid-pipeline ===")
+	ctx, cancel := context.WithCancel(context.Background())
+	out := Square(ctx, Square(ctx, Generator(ctx, 1, 2, 3, 4, 5, 6, 7, 8)))
+	for i := 0; i < 3; i++ {
+		fmt.Println("received", <-out)
+	}
+	cancel() // stop reading; every stage notices and exits (see pipeline_test.go)
+}
@@ -0,0 +1,53 @@
+package main
+
+import (
+	"context"
+	"reflect"
+	"runtime"
+	"testing"
+	"time"
+)
+
+func TestPipeline(t *testing.T) {
+	ctx := context.Background()
+	var got []int
+	for v := range Square(ctx, Generator(ctx, 1, 2, 3, 4)) {
+		got = append(got, v)
+	}
+	if want := []int{1, 4, 9, 16}; !reflect.DeepEqual(got, want) {
+		t.Errorf("pipeline produced %v, want %v", got, want)
+	}
+}
+
+func TestPipelineCancelDoesNotLeak(t *testing.T) {
+	before := runtime.NumGoroutine()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	nums := make([]int, 1000)
+	for i := range nums {
+		nums[i] = i
+	}
+	out := Square(ctx, Square(ctx, Generator(ctx, nums...)))
+	for i := 0; i < 3; i++ {
+		<-out
+	}
+	cancel() // stop reading; every stage must notice and exit
+
+	received := 3
+	for range out {
+		received++ // whatever was in flight before the stages saw ctx.Done()
+	}
+	if received == len(nums) {
+		t.Errorf("received all %d values; cancelling did not stop the pipeline", received)
+	}
+
+	// Stage goroutines exit just after closing their channels, so allow them
+	// a moment to be gone
+	deadline := time.Now().Add(time.Second)
+	for runtime.NumGoroutine() > before && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if after := runtime.NumGoroutine(); after > before {
+		t.Errorf("goroutines before = %d, after = %d; pipeline stages leaked", before, after)
+	}
+}
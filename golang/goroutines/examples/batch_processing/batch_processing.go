@@ -1,8 +1,10 @@
 package main
 
 import (
+	"errors"
 	"fmt"
 	"math/rand"
+	"sort"
 	"sync"
 	"time"
 )
@@ -13,6 +15,9 @@ type DataChunk struct {
 	Items []int
 }
 
+// ErrEmptyChunk is reported for a chunk without items, which has no average
+var ErrEmptyChunk = errors.New("chunk has no items")
+
 // ProcessingResult represents the result of processing a data chunk
 type ProcessingResult struct {
 	ChunkID int
@@ -20,19 +25,67 @@ type ProcessingResult struct {
 	Average float64
 }
 
+// Result carries either a value or the error that prevented computing it,
+// so both can travel over a single channel
+type Result[T any] struct {
+	Value T
+	Err   error
+}
+
+// Ok wraps a successful value
+func Ok[T any](v T) Result[T] {
+	return Result[T]{Value: v}
+}
+
+// Err wraps a failure
+func Err[T any](e error) Result[T] {
+	return Result[T]{Err: e}
+}
+
+// Unwrap returns the value and error, in the usual Go order
+func (r Result[T]) Unwrap() (T, error) {
+	return r.Value, r.Err
+}
+
+// ProcessFunc turns one chunk into a result. It runs concurrently on the
+// worker goroutines, so it must not share unsynchronized state.
+type ProcessFunc func(DataChunk) (ProcessingResult, error)
+
+// SumAndAverage is the default ProcessFunc computing the chunk's sum and mean
+func SumAndAverage(chunk DataChunk) (ProcessingResult, error) {
+	if len(chunk.Items) == 0 {
+		return ProcessingResult{}, ErrEmptyChunk
+	}
+	sum := 0
+	for _, item := range chunk.Items {
+		sum += item
+	}
+	return ProcessingResult{
+		Sum:     sum,
+		Average: float64(sum) / float64(len(chunk.Items)),
+	}, nil
+}
+
 // BatchProcessor handles parallel processing of data chunks
 type BatchProcessor struct {
+	process    ProcessFunc
 	inputChan  chan DataChunk
-	resultChan chan ProcessingResult
+	resultChan chan Result[ProcessingResult]
 	workerWg   sync.WaitGroup
 	resultWg   sync.WaitGroup
 }
 
-// NewBatchProcessor creates a new batch processor_unused with specified number of workers
-func NewBatchProcessor(numWorkers int) *BatchProcessor {
+// NewBatchProcessor creates a new batch processor with specified number of
+// workers, each running process on the chunks it receives. A nil process
+// uses SumAndAverage.
+func NewBatchProcessor(numWorkers int, process ProcessFunc) *BatchProcessor {
+	if process == nil {
+		process = SumAndAverage
+	}
 	bp := &BatchProcessor{
+		process:    process,
 		inputChan:  make(chan DataChunk, numWorkers),
-		resultChan: make(chan ProcessingResult, numWorkers),
+		resultChan: make(chan Result[ProcessingResult], numWorkers),
 	}
 
 	// Start workers
@@ -49,32 +102,32 @@ func (bp *BatchProcessor) worker(id int) {
 	defer bp.workerWg.Done()
 
 	for chunk := range bp.inputChan {
-		// Simulate complex processing
-		time.Sleep(time.Duration(rand.Intn(500)) * time.Millisecond)
-
-		// Process the chunk
-		sum := 0
-		for _, item := range chunk.Items {
-			sum += item
+		result, err := bp.process(chunk)
+		result.ChunkID = chunk.ID
+		if err != nil {
+			failed := Err[ProcessingResult](fmt.Errorf("chunk %d: %w", chunk.ID, err))
+			failed.Value.ChunkID = chunk.ID // so failures can be ordered too
+			bp.resultChan <- failed
+			fmt.Printf("Worker %d failed chunk %d: %v\n", id, chunk.ID, err)
+			continue
 		}
-		average := float64(sum) / float64(len(chunk.Items))
 
 		// Send result
-		result := ProcessingResult{
-			ChunkID: chunk.ID,
-			Sum:     sum,
-			Average: average,
-		}
-		bp.resultChan <- result
+		bp.resultChan <- Ok(result)
 
 		fmt.Printf("Worker %d processed chunk %d: Sum = %d, Average = %.2f\n",
-			id, chunk.ID, sum, average)
+			id, chunk.ID, result.Sum, result.Average)
 	}
 }
 
-// ProcessBatches processes multiple data chunks and collects results
-func (bp *BatchProcessor) ProcessBatches(chunks []DataChunk) []ProcessingResult {
-	results := make([]ProcessingResult, 0, len(chunks))
+// ProcessBatches processes multiple data chunks and collects results.
+// Every chunk gets a result and the results are sorted by ChunkID, so the
+// output doesn't depend on which worker finished first. A failed chunk's
+// result holds its error and, in Value, only its ChunkID. The returned error
+// joins the errors of all chunks that failed and is nil if every chunk
+// succeeded.
+func (bp *BatchProcessor) ProcessBatches(chunks []DataChunk) ([]Result[ProcessingResult], error) {
+	results := make([]Result[ProcessingResult], 0, len(chunks))
 	resultsMutex := sync.Mutex{}
 
 	// Start result collector
@@ -103,7 +156,18 @@ func (bp *BatchProcessor) ProcessBatches(chunks []DataChunk) []ProcessingResult
 	close(bp.resultChan)
 	bp.resultWg.Wait()
 
-	return results
+	// Workers finish in random order; restore a deterministic order
+	sort.SliceStable(results, func(i, j int) bool {
+		return results[i].Value.ChunkID < results[j].Value.ChunkID
+	})
+
+	var errs []error
+	for _, result := range results {
+		if result.Err != nil {
+			errs = append(errs, result.Err)
+		}
+	}
+	return results, errors.Join(errs...)
 }
 
 // generateTestData creates sample data chunks for processing
@@ -129,28 +193,49 @@ func main() {
 	numChunks := 10
 	chunkSize := 1000
 	chunks := generateTestData(numChunks, chunkSize)
+	chunks = append(chunks, DataChunk{ID: numChunks}) // empty chunk reports an error
 
-	// Create batch processor_unused with 4 workers
-	processor := NewBatchProcessor(4)
+	// Create batch processor with 4 workers running the sum/average
+	// reduction, with a random delay to simulate complex processing
+	processor := NewBatchProcessor(4, func(chunk DataChunk) (ProcessingResult, error) {
+		time.Sleep(time.Duration(rand.Intn(500)) * time.Millisecond)
+		return SumAndAverage(chunk)
+	})
 
 	// Process batches and collect results
 	fmt.Println("Starting batch processing...")
 	startTime := time.Now()
 
-	results := processor.ProcessBatches(chunks)
+	results, err := processor.ProcessBatches(chunks)
+	if err != nil {
+		fmt.Printf("Some chunks failed: %v\n", err)
+	}
 
-	// Calculate total statistics
+	// Calculate total statistics over the chunks that succeeded
 	totalSum := 0
 	totalAverage := 0.0
-	for _, result := range results {
+	succeeded := 0
+	for _, r := range results {
+		result, err := r.Unwrap()
+		if err != nil {
+			continue
+		}
 		totalSum += result.Sum
 		totalAverage += result.Average
+		succeeded++
+	}
+	if succeeded > 0 {
+		totalAverage /= float64(succeeded)
 	}
-	totalAverage /= float64(len(results))
 
 	// Print final results
 	fmt.Printf("\nProcessing completed in %v\n", time.Since(startTime))
-	fmt.Printf("Total chunks processed: %d\n", len(results))
+	fmt.Printf("Total chunks processed: %d (%d failed)\n", len(results), len(results)-succeeded)
 	fmt.Printf("Total sum: %d\n", totalSum)
 	fmt.Printf("Overall average: %.2f\n", totalAverage)
+
+	fmt.Println("\nResults in chunk order:")
+	for _, result := range results {
+		fmt.Printf("  chunk %d: sum=%d err=%v\n", result.Value.ChunkID, result.Value.Sum, result.Err)
+	}
 }
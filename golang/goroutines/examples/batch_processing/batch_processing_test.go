@@ -0,0 +1,137 @@
+package main
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestSumAndAverageEmptyChunk(t *testing.T) {
+	if _, err := SumAndAverage(DataChunk{ID: 1}); !errors.Is(err, ErrEmptyChunk) {
+		t.Errorf("SumAndAverage(empty) error = %v, want ErrEmptyChunk", err)
+	}
+}
+
+func TestProcessBatchesEmptyChunk(t *testing.T) {
+	chunks := []DataChunk{
+		{ID: 0, Items: []int{1, 2, 3}},
+		{ID: 1}, // no items
+		{ID: 2, Items: []int{10}},
+	}
+	results, err := NewBatchProcessor(2, nil).ProcessBatches(chunks)
+	if !errors.Is(err, ErrEmptyChunk) {
+		t.Fatalf("ProcessBatches error = %v, want it to wrap ErrEmptyChunk", err)
+	}
+	if len(results) != len(chunks) {
+		t.Fatalf("got %d results, want %d", len(results), len(chunks))
+	}
+
+	failed := 0
+	for _, r := range results {
+		if r.Err == nil {
+			continue
+		}
+		failed++
+		if r.Value.ChunkID != 1 {
+			t.Errorf("chunk %d failed, want only chunk 1 to fail", r.Value.ChunkID)
+		}
+	}
+	if failed != 1 {
+		t.Errorf("%d chunks failed, want 1", failed)
+	}
+}
+
+func TestProcessBatchesNoErrors(t *testing.T) {
+	chunks := []DataChunk{{ID: 0, Items: []int{2, 4}}}
+	if _, err := NewBatchProcessor(1, nil).ProcessBatches(chunks); err != nil {
+		t.Errorf("ProcessBatches error = %v, want nil", err)
+	}
+}
+
+func TestProcessBatchesCustomProcessFunc(t *testing.T) {
+	// A deterministic reduction: the largest item becomes the Sum
+	maxItem := func(chunk DataChunk) (ProcessingResult, error) {
+		if len(chunk.Items) == 0 {
+			return ProcessingResult{}, ErrEmptyChunk
+		}
+		max := chunk.Items[0]
+		for _, item := range chunk.Items[1:] {
+			if item > max {
+				max = item
+			}
+		}
+		return ProcessingResult{Sum: max}, nil
+	}
+	chunks := []DataChunk{
+		{ID: 0, Items: []int{3, 9, 1}},
+		{ID: 1, Items: []int{-5, -2}},
+	}
+	results, err := NewBatchProcessor(2, maxItem).ProcessBatches(chunks)
+	if err != nil {
+		t.Fatalf("ProcessBatches error = %v", err)
+	}
+	want := map[int]int{0: 9, 1: -2}
+	for _, r := range results {
+		if r.Value.Sum != want[r.Value.ChunkID] {
+			t.Errorf("chunk %d: got %d, want %d", r.Value.ChunkID, r.Value.Sum, want[r.Value.ChunkID])
+		}
+	}
+}
+
+func TestProcessBatchesProcessFuncError(t *testing.T) {
+	boom := errors.New("boom")
+	results, err := NewBatchProcessor(1, func(DataChunk) (ProcessingResult, error) {
+		return ProcessingResult{}, boom
+	}).ProcessBatches([]DataChunk{{ID: 7, Items: []int{1}}})
+	if !errors.Is(err, boom) {
+		t.Errorf("ProcessBatches error = %v, want it to wrap %v", err, boom)
+	}
+	if len(results) != 1 || !errors.Is(results[0].Err, boom) || results[0].Value.ChunkID != 7 {
+		t.Errorf("results = %+v, want one failed result for chunk 7", results)
+	}
+}
+
+func TestProcessBatchesSortedByChunkID(t *testing.T) {
+	var chunks []DataChunk
+	var want []Result[ProcessingResult]
+	for id := 19; id >= 0; id-- {
+		chunks = append(chunks, DataChunk{ID: id, Items: []int{id, id + 2}})
+	}
+	for id := 0; id < 20; id++ {
+		want = append(want, Ok(ProcessingResult{ChunkID: id, Sum: 2*id + 2, Average: float64(id + 1)}))
+	}
+
+	results, err := NewBatchProcessor(4, nil).ProcessBatches(chunks)
+	if err != nil {
+		t.Fatalf("ProcessBatches error = %v", err)
+	}
+	if !reflect.DeepEqual(results, want) {
+		t.Errorf("results = %+v, want %+v", results, want)
+	}
+}
+
+func TestResultHelpers(t *testing.T) {
+	value, err := Ok(42).Unwrap()
+	if value != 42 || err != nil {
+		t.Errorf("Ok(42).Unwrap() = %d, %v, want 42, nil", value, err)
+	}
+
+	value, err = Err[int](ErrEmptyChunk).Unwrap()
+	if value != 0 || !errors.Is(err, ErrEmptyChunk) {
+		t.Errorf("Err(ErrEmptyChunk).Unwrap() = %d, %v, want 0, ErrEmptyChunk", value, err)
+	}
+
+	// Ok of a zero value is still a success, and the zero Result is Ok too
+	if _, err := Ok("").Unwrap(); err != nil {
+		t.Errorf(`Ok("").Unwrap() err = %v, want nil`, err)
+	}
+	var zero Result[ProcessingResult]
+	if got, err := zero.Unwrap(); err != nil || got != (ProcessingResult{}) {
+		t.Errorf("zero Result.Unwrap() = %+v, %v, want the zero value and nil", got, err)
+	}
+
+	result := Ok(ProcessingResult{ChunkID: 3, Sum: 6, Average: 2})
+	if got, _ := result.Unwrap(); got != result.Value {
+		t.Errorf("Unwrap() value = %+v, want %+v", got, result.Value)
+	}
+}
@@ -0,0 +1,187 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"time"
+)
+
+// ErrCircuitOpen is returned by Execute while the breaker is rejecting calls
+var ErrCircuitOpen = errors.New("circuit breaker is open")
+
+// State is the state of a CircuitBreaker
+type State int
+
+const (
+	StateClosed   State = iota // calls pass through; failures are counted
+	StateOpen                  // calls are rejected until the cooldown ends
+	StateHalfOpen              // one probe call decides whether to close again
+)
+
+func (s State) String() string {
+	switch s {
+	case StateClosed:
+		return "closed"
+	case StateOpen:
+		return "open"
+	default:
+		return "half-open"
+	}
+}
+
+// CircuitBreaker stops calling a failing dependency for a while so it can
+// recover. After threshold consecutive failures it opens and rejects calls
+// with ErrCircuitOpen. Once cooldown has passed it lets a single probe call
+// through: success closes the breaker, failure opens it for another cooldown.
+type CircuitBreaker struct {
+	mu        sync.Mutex
+	threshold int
+	cooldown  time.Duration
+	now       func() time.Time // replaceable so demos don't have to sleep
+
+	state    State
+	failures int
+	openedAt time.Time
+	probing  bool // a half-open probe is in flight
+}
+
+// NewCircuitBreaker creates a closed breaker that opens after threshold
+// consecutive failures and stays open for cooldown
+func NewCircuitBreaker(threshold int, cooldown time.Duration) *CircuitBreaker {
+	return &CircuitBreaker{threshold: threshold, cooldown: cooldown, now: time.Now}
+}
+
+// State returns the current state, moving from open to half-open if the
+// cooldown has passed
+func (cb *CircuitBreaker) State() State {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	cb.refresh()
+	return cb.state
+}
+
+func (cb *CircuitBreaker) refresh() {
+	if cb.state == StateOpen && cb.now().Sub(cb.openedAt) >= cb.cooldown {
+		cb.state = StateHalfOpen
+	}
+}
+
+// Execute runs fn unless the breaker is open, and records the outcome.
+// While half-open only one call at a time is let through as a probe. If fn
+// panics, the call counts as a failure and the panic carries on up.
+func (cb *CircuitBreaker) Execute(ctx context.Context, fn func(ctx context.Context) error) error {
+	cb.mu.Lock()
+	cb.refresh()
+	if cb.state == StateOpen || (cb.state == StateHalfOpen && cb.probing) {
+		cb.mu.Unlock()
+		return ErrCircuitOpen
+	}
+	if cb.state == StateHalfOpen {
+		cb.probing = true
+	}
+	cb.mu.Unlock()
+
+	// Record the outcome in a defer so a panicking fn can't leave a probe
+	// marked as in flight, which would reject every later call
+	failed := true
+	defer func() { cb.record(failed) }()
+	err := fn(ctx)
+	failed = err != nil
+	return err
+}
+
+// record updates the breaker with the outcome of a call Execute let through
+func (cb *CircuitBreaker) record(failed bool) {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	cb.probing = false
+	if !failed {
+		cb.state = StateClosed
+		cb.failures = 0
+		return
+	}
+	cb.failures++
+	if cb.state == StateHalfOpen || cb.failures >= cb.threshold {
+		cb.state = StateOpen
+		cb.openedAt = cb.now()
+		cb.failures = 0
+	}
+}
+
+// fakeClock is a manually advanced clock for the demo
+type fakeClock struct {
+	mu sync.Mutex
+	t  time.Time
+}
+
+func (f *fakeClock) Now() time.Time {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.t
+}
+
+func (f *fakeClock) Advance(d time.Duration) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.t = f.t.Add(d)
+}
+
+func main() {
+	// A backend that fails until it is marked healthy
+	var mu sync.Mutex
+	healthy := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		if !healthy {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	call := func(ctx context.Context) error {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+		if err != nil {
+			return err
+		}
+		resp, err := server.Client().Do(req)
+		if err != nil {
+			return err
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode >= 500 {
+			return fmt.Errorf("server returned %s", resp.Status)
+		}
+		return nil
+	}
+
+	clock := &fakeClock{t: time.Now()}
+	breaker := NewCircuitBreaker(3, 10*time.Second)
+	breaker.now = clock.Now
+	ctx := context.Background()
+
+	for i := 1; i <= 5; i++ {
+		err := breaker.Execute(ctx, call)
+		fmt.Printf("call %d: err=%v state=%s\n", i, err, breaker.State())
+	}
+
+	clock.Advance(5 * time.Second)
+	fmt.Println("after 5s:", breaker.Execute(ctx, call), "state:", breaker.State())
+
+	clock.Advance(5 * time.Second) // cooldown elapsed: probe allowed, but still failing
+	fmt.Println("state after cooldown:", breaker.State())
+	fmt.Println("failed probe:", breaker.Execute(ctx, call), "state:", breaker.State())
+
+	mu.Lock()
+	healthy = true
+	mu.Unlock()
+	clock.Advance(10 * time.Second)
+	fmt.Println("successful probe err:", breaker.Execute(ctx, call), "state:", breaker.State())
+	fmt.Println("rejected?", errors.Is(breaker.Execute(ctx, call), ErrCircuitOpen))
+}
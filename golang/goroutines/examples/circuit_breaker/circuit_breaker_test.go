@@ -0,0 +1,137 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+var errBackend = errors.New("backend down")
+
+func fail(context.Context) error    { return errBackend }
+func succeed(context.Context) error { return nil }
+
+// newTestBreaker returns a breaker that opens after 3 failures for 10s,
+// driven by a fake clock
+func newTestBreaker() (*CircuitBreaker, *fakeClock) {
+	clock := &fakeClock{t: time.Now()}
+	breaker := NewCircuitBreaker(3, 10*time.Second)
+	breaker.now = clock.Now
+	return breaker, clock
+}
+
+func TestBreakerTripsAfterThreshold(t *testing.T) {
+	breaker, _ := newTestBreaker()
+	ctx := context.Background()
+
+	for i := 1; i <= 3; i++ {
+		if err := breaker.Execute(ctx, fail); !errors.Is(err, errBackend) {
+			t.Fatalf("call %d: err = %v, want the backend error", i, err)
+		}
+	}
+	if got := breaker.State(); got != StateOpen {
+		t.Fatalf("State() after 3 failures = %s, want open", got)
+	}
+
+	called := false
+	err := breaker.Execute(ctx, func(context.Context) error {
+		called = true
+		return nil
+	})
+	if !errors.Is(err, ErrCircuitOpen) || called {
+		t.Errorf("Execute while open = %v (fn called: %v), want ErrCircuitOpen without calling fn", err, called)
+	}
+}
+
+func TestBreakerSuccessResetsFailureCount(t *testing.T) {
+	breaker, _ := newTestBreaker()
+	ctx := context.Background()
+	breaker.Execute(ctx, fail)
+	breaker.Execute(ctx, fail)
+	breaker.Execute(ctx, succeed)
+	breaker.Execute(ctx, fail)
+	breaker.Execute(ctx, fail)
+	if got := breaker.State(); got != StateClosed {
+		t.Errorf("State() = %s, want closed: failures were not consecutive", got)
+	}
+}
+
+func TestBreakerRejectsUntilCooldown(t *testing.T) {
+	breaker, clock := newTestBreaker()
+	ctx := context.Background()
+	for i := 0; i < 3; i++ {
+		breaker.Execute(ctx, fail)
+	}
+
+	clock.Advance(9 * time.Second)
+	if err := breaker.Execute(ctx, succeed); !errors.Is(err, ErrCircuitOpen) {
+		t.Errorf("Execute before the cooldown = %v, want ErrCircuitOpen", err)
+	}
+
+	clock.Advance(time.Second)
+	if got := breaker.State(); got != StateHalfOpen {
+		t.Fatalf("State() after the cooldown = %s, want half-open", got)
+	}
+	// A failed probe opens the breaker for another cooldown
+	if err := breaker.Execute(ctx, fail); !errors.Is(err, errBackend) {
+		t.Errorf("probe err = %v, want the backend error", err)
+	}
+	if got := breaker.State(); got != StateOpen {
+		t.Fatalf("State() after a failed probe = %s, want open", got)
+	}
+
+	clock.Advance(10 * time.Second)
+	if err := breaker.Execute(ctx, succeed); err != nil {
+		t.Errorf("successful probe err = %v, want nil", err)
+	}
+	if got := breaker.State(); got != StateClosed {
+		t.Errorf("State() after a successful probe = %s, want closed", got)
+	}
+}
+
+func TestBreakerAllowsOneProbeAtATime(t *testing.T) {
+	breaker, clock := newTestBreaker()
+	ctx := context.Background()
+	for i := 0; i < 3; i++ {
+		breaker.Execute(ctx, fail)
+	}
+	clock.Advance(10 * time.Second)
+
+	var second error
+	breaker.Execute(ctx, func(context.Context) error {
+		second = breaker.Execute(ctx, succeed) // arrives while the probe runs
+		return nil
+	})
+	if !errors.Is(second, ErrCircuitOpen) {
+		t.Errorf("second call during the probe = %v, want ErrCircuitOpen", second)
+	}
+}
+
+func TestBreakerRecoversFromPanickingProbe(t *testing.T) {
+	breaker, clock := newTestBreaker()
+	ctx := context.Background()
+	for i := 0; i < 3; i++ {
+		breaker.Execute(ctx, fail)
+	}
+	clock.Advance(10 * time.Second)
+
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Error("panic from fn was swallowed, want it to propagate")
+			}
+		}()
+		breaker.Execute(ctx, func(context.Context) error { panic("boom") })
+	}()
+
+	// The panic counted as a failed probe, so the breaker is open again
+	// rather than stuck rejecting every call with a probe "in flight"
+	if got := breaker.State(); got != StateOpen {
+		t.Fatalf("State() after a panicking probe = %s, want open", got)
+	}
+	clock.Advance(10 * time.Second)
+	if err := breaker.Execute(ctx, succeed); err != nil {
+		t.Errorf("probe after the next cooldown = %v, want nil", err)
+	}
+}
@@ -0,0 +1,104 @@
+package main
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// debouncer runs fn once calls have stopped arriving for a quiet period
+type debouncer struct {
+	mu      sync.Mutex
+	d       time.Duration
+	fn      func()
+	timer   *time.Timer
+	stopped bool
+}
+
+// Debounce wraps fn so that it runs only after d has passed without another
+// call. A burst of calls results in one run of fn. Both call and stop are safe
+// to use from many goroutines. stop cancels a pending run and makes later
+// calls do nothing; it does not wait for a run of fn that has already started.
+func Debounce(d time.Duration, fn func()) (call func(), stop func()) {
+	db := &debouncer{d: d, fn: fn}
+	return db.call, db.stop
+}
+
+// call (re)starts the quiet-period timer
+func (db *debouncer) call() {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	if db.stopped {
+		return
+	}
+	if db.timer != nil {
+		db.timer.Stop()
+	}
+	db.timer = time.AfterFunc(db.d, db.fn)
+}
+
+func (db *debouncer) stop() {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	db.stopped = true
+	if db.timer != nil {
+		db.timer.Stop()
+		db.timer = nil
+	}
+}
+
+// throttler runs fn at most once per interval
+type throttler struct {
+	mu      sync.Mutex
+	d       time.Duration
+	fn      func()
+	last    time.Time
+	stopped bool
+}
+
+// Throttle wraps fn so that a call runs it straight away unless fn already
+// ran less than d ago, in which case the call is dropped. Both call and stop
+// are safe to use from many goroutines. call runs fn on the caller's goroutine
+// and outside the lock, so stop only makes later calls do nothing: it can
+// return while an earlier call is still inside fn.
+func Throttle(d time.Duration, fn func()) (call func(), stop func()) {
+	t := &throttler{d: d, fn: fn}
+	return t.call, t.stop
+}
+
+// call runs fn if the interval has passed
+func (t *throttler) call() {
+	t.mu.Lock()
+	if t.stopped || (!t.last.IsZero() && time.Since(t.last) < t.d) {
+		t.mu.Unlock()
+		return
+	}
+	t.last = time.Now()
+	t.mu.Unlock()
+	t.fn() // run outside the lock so a slow fn doesn't block callers
+}
+
+func (t *throttler) stop() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.stopped = true
+}
+
+func main() {
+	// A burst of calls 5ms apart runs the debounced fn once, 50ms after the last
+	debounce, stopDebounce := Debounce(50*time.Millisecond, func() { fmt.Println("debounced run") })
+	defer stopDebounce()
+	for i := 0; i < 10; i++ {
+		debounce()
+		time.Sleep(5 * time.Millisecond)
+	}
+	time.Sleep(100 * time.Millisecond)
+
+	// The same burst runs the throttled fn at the start and once 30ms later
+	throttle, stopThrottle := Throttle(30*time.Millisecond, func() { fmt.Println("throttled run") })
+	defer stopThrottle()
+	for i := 0; i < 10; i++ {
+		throttle()
+		time.Sleep(5 * time.Millisecond)
+	}
+}
@@ -0,0 +1,94 @@
+package main
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// burst calls call every interval for duration, from several goroutines
+func burst(call func(), duration, interval time.Duration) {
+	var wg sync.WaitGroup
+	for g := 0; g < 4; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for end := time.Now().Add(duration); time.Now().Before(end); time.Sleep(interval) {
+				call()
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func TestDebounceFiresOncePerBurst(t *testing.T) {
+	var runs atomic.Int32
+	debounce, stop := Debounce(50*time.Millisecond, func() { runs.Add(1) })
+	defer stop()
+
+	burst(debounce, 100*time.Millisecond, 5*time.Millisecond)
+	if got := runs.Load(); got != 0 {
+		t.Errorf("runs during the burst = %d, want 0", got)
+	}
+	time.Sleep(150 * time.Millisecond)
+	if got := runs.Load(); got != 1 {
+		t.Fatalf("runs after the first burst = %d, want 1", got)
+	}
+
+	burst(debounce, 100*time.Millisecond, 5*time.Millisecond)
+	time.Sleep(150 * time.Millisecond)
+	if got := runs.Load(); got != 2 {
+		t.Errorf("runs after the second burst = %d, want 2", got)
+	}
+}
+
+func TestDebounceStopCancelsPendingRun(t *testing.T) {
+	var runs atomic.Int32
+	debounce, stop := Debounce(20*time.Millisecond, func() { runs.Add(1) })
+
+	debounce()
+	stop()
+	debounce() // ignored once stopped
+	time.Sleep(80 * time.Millisecond)
+	if got := runs.Load(); got != 0 {
+		t.Errorf("runs after stop = %d, want 0", got)
+	}
+}
+
+func TestThrottleFiresAtMostOncePerInterval(t *testing.T) {
+	var runs atomic.Int32
+	throttle, stop := Throttle(100*time.Millisecond, func() { runs.Add(1) })
+	defer stop()
+
+	// The first call runs straight away
+	throttle()
+	if got := runs.Load(); got != 1 {
+		t.Fatalf("runs after the first call = %d, want 1", got)
+	}
+	throttle()
+	if got := runs.Load(); got != 1 {
+		t.Errorf("runs after an immediate second call = %d, want 1", got)
+	}
+
+	// Calls every 5ms for ~500ms can't run more than once per 100ms, and
+	// a steady stream of calls shouldn't leave an interval unused either;
+	// allow one run of slack for scheduling
+	burst(throttle, 500*time.Millisecond, 5*time.Millisecond)
+	if got := runs.Load(); got < 5 || got > 7 {
+		t.Errorf("runs after a 500ms burst = %d, want 5 to 7", got)
+	}
+}
+
+func TestThrottleStopDropsLaterCalls(t *testing.T) {
+	var runs atomic.Int32
+	throttle, stop := Throttle(time.Millisecond, func() { runs.Add(1) })
+
+	throttle()
+	stop()
+	time.Sleep(5 * time.Millisecond)
+	throttle()
+	if got := runs.Load(); got != 1 {
+		t.Errorf("runs = %d, want 1: calls after stop must be dropped", got)
+	}
+}
@@ -0,0 +1,101 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// In the dining philosophers problem n philosophers sit at a round table with
+// a fork between each pair, and each needs both neighbouring forks to eat. If
+// every philosopher picks up the left fork first they can all end up holding
+// one fork and waiting forever for the other: a deadlock, because the waits
+// form a cycle.
+//
+// Acquiring locks in a fixed global order breaks the cycle. Every philosopher
+// picks up the lower-numbered of their two forks first, so the last
+// philosopher reaches for fork 0 before fork n-1, and at least one
+// philosopher can always get both forks.
+
+// DiningPhilosophers simulates philosophers sharing forks
+type DiningPhilosophers struct {
+	forks   []sync.Mutex
+	eatTime time.Duration
+}
+
+// NewDiningPhilosophers seats n philosophers, each of whom eats for eatTime
+// per meal
+func NewDiningPhilosophers(n int, eatTime time.Duration) *DiningPhilosophers {
+	return &DiningPhilosophers{forks: make([]sync.Mutex, n), eatTime: eatTime}
+}
+
+// Run lets every philosopher try to eat rounds times, concurrently, and
+// returns how many meals each one had. It returns early if ctx is done, in
+// which case the counts are the meals finished so far. Run never hangs: the
+// lock ordering rules out deadlock, and each meal gives up as soon as ctx is
+// done.
+func (d *DiningPhilosophers) Run(ctx context.Context, rounds int) []int {
+	n := len(d.forks)
+	meals := make([]int, n)
+	var wg sync.WaitGroup
+	for p := 0; p < n; p++ {
+		wg.Add(1)
+		go func(p int) {
+			defer wg.Done()
+			left, right := p, (p+1)%n
+			first, second := min(left, right), max(left, right)
+			alone := first == second // a lone philosopher has only one fork
+			for round := 0; round < rounds; round++ {
+				if ctx.Err() != nil {
+					return
+				}
+				d.forks[first].Lock()
+				if !alone {
+					d.forks[second].Lock()
+				}
+				ate := d.eat(ctx)
+				if !alone {
+					d.forks[second].Unlock()
+				}
+				d.forks[first].Unlock()
+				if !ate {
+					return
+				}
+				meals[p]++ // only this goroutine writes meals[p]
+			}
+		}(p)
+	}
+	wg.Wait()
+	return meals
+}
+
+// eat holds the forks for eatTime, reporting false if ctx ended the meal early
+func (d *DiningPhilosophers) eat(ctx context.Context) bool {
+	timer := time.NewTimer(d.eatTime)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+func main() {
+	const philosophers = 5
+	const rounds = 3
+	table := NewDiningPhilosophers(philosophers, 5*time.Millisecond)
+
+	fmt.Println("Meals:", table.Run(context.Background(), rounds)) // [3 3 3 3 3]
+
+	// Under a timeout Run returns promptly with the meals finished so far
+	slow := NewDiningPhilosophers(philosophers, 20*time.Millisecond)
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	fmt.Println("Meals before timeout:", slow.Run(ctx, 1000))
+
+	// Two philosophers share both forks, the smallest table that can deadlock
+	fmt.Println("Two philosophers:", NewDiningPhilosophers(2, time.Millisecond).Run(context.Background(), 10))
+	fmt.Println("One philosopher:", NewDiningPhilosophers(1, time.Millisecond).Run(context.Background(), 10))
+}
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// runWithin calls Run and fails the test if it doesn't return within limit
+func runWithin(t *testing.T, ctx context.Context, d *DiningPhilosophers, rounds int, limit time.Duration) []int {
+	t.Helper()
+	done := make(chan []int, 1)
+	go func() { done <- d.Run(ctx, rounds) }()
+	select {
+	case meals := <-done:
+		return meals
+	case <-time.After(limit):
+		t.Fatalf("Run(%d rounds) with %d philosophers didn't return within %v", rounds, len(d.forks), limit)
+		return nil
+	}
+}
+
+func TestDiningPhilosophersEveryoneEats(t *testing.T) {
+	for _, n := range []int{1, 2, 3, 5} {
+		const rounds = 3
+		meals := runWithin(t, context.Background(), NewDiningPhilosophers(n, time.Millisecond), rounds, 5*time.Second)
+		if len(meals) != n {
+			t.Fatalf("%d philosophers: got %d meal counts", n, len(meals))
+		}
+		for p, m := range meals {
+			if m != rounds {
+				t.Errorf("%d philosophers: philosopher %d ate %d times, want %d", n, p, m, rounds)
+			}
+		}
+	}
+}
+
+// Meals that take no time maximize contention for the forks, which is when
+// left-fork-first locking would deadlock
+func TestDiningPhilosophersNoDeadlockUnderContention(t *testing.T) {
+	const n, rounds = 5, 2000
+	meals := runWithin(t, context.Background(), NewDiningPhilosophers(n, 0), rounds, 10*time.Second)
+	for p, m := range meals {
+		if m != rounds {
+			t.Errorf("philosopher %d ate %d times, want %d", p, m, rounds)
+		}
+	}
+}
+
+func TestDiningPhilosophersReturnsOnTimeout(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	meals := runWithin(t, ctx, NewDiningPhilosophers(5, 20*time.Millisecond), 1000, time.Second)
+
+	total := 0
+	for _, m := range meals {
+		total += m
+	}
+	// At most two of five philosophers eat at once, 20ms a meal, for 50ms
+	if total == 0 || total > 10 {
+		t.Errorf("meals before the timeout = %v, want between 1 and 10 in total", meals)
+	}
+}
@@ -0,0 +1,178 @@
+package main
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// OverflowPolicy decides what Publish does when a subscriber's buffer is full
+type OverflowPolicy int
+
+const (
+	DropNewest OverflowPolicy = iota // the new event is not delivered to that subscriber
+	DropOldest                       // the subscriber's oldest buffered event makes room for the new one
+)
+
+// EventBus fans each published event out to every current subscriber. Each
+// subscriber has its own buffered channel, and Publish never waits on a full
+// one, so a slow subscriber loses events instead of stalling the publisher
+// and everyone else.
+type EventBus[T any] struct {
+	mu          sync.RWMutex
+	subscribers map[<-chan T]chan T
+	buffer      int
+	policy      OverflowPolicy
+	closed      bool
+	dropped     atomic.Int64
+}
+
+// NewEventBus creates a bus whose subscribers each buffer up to buffer events
+func NewEventBus[T any](buffer int, policy OverflowPolicy) *EventBus[T] {
+	return &EventBus[T]{
+		subscribers: make(map[<-chan T]chan T),
+		buffer:      buffer,
+		policy:      policy,
+	}
+}
+
+// Subscribe returns a channel that receives every event published from now
+// on. On a closed bus the returned channel is already closed.
+func (b *EventBus[T]) Subscribe() <-chan T {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	ch := make(chan T, b.buffer)
+	if b.closed {
+		close(ch)
+		return ch
+	}
+	b.subscribers[ch] = ch
+	return ch
+}
+
+// Unsubscribe stops delivery to ch and closes it. Unknown channels are ignored.
+func (b *EventBus[T]) Unsubscribe(ch <-chan T) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if sub, ok := b.subscribers[ch]; ok {
+		delete(b.subscribers, ch)
+		close(sub)
+	}
+}
+
+// Publish delivers event to every subscriber without blocking. Publishing
+// on a closed bus does nothing.
+func (b *EventBus[T]) Publish(event T) {
+	// The read lock is enough: it keeps Unsubscribe and Close from closing a
+	// channel while we send on it
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	if b.closed {
+		return
+	}
+	for _, sub := range b.subscribers {
+		b.deliver(sub, event)
+	}
+}
+
+func (b *EventBus[T]) deliver(sub chan T, event T) {
+	for {
+		select {
+		case sub <- event:
+			return
+		default:
+		}
+		if b.policy == DropNewest {
+			b.dropped.Add(1)
+			return
+		}
+		// DropOldest: discard the head of the buffer and try again. The
+		// subscriber may have drained it in the meantime, which is fine.
+		select {
+		case <-sub:
+			b.dropped.Add(1)
+		default:
+		}
+	}
+}
+
+// Dropped returns how many events have been dropped across all subscribers
+func (b *EventBus[T]) Dropped() int64 {
+	return b.dropped.Load()
+}
+
+// Close closes every subscriber channel. Later Publish calls do nothing and
+// later subscribers get a closed channel. Close is safe to call more than once.
+func (b *EventBus[T]) Close() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.closed {
+		return
+	}
+	b.closed = true
+	for ch, sub := range b.subscribers {
+		delete(b.subscribers, ch)
+		close(sub)
+	}
+}
+
+// collect reads events from ch until it is closed, sleeping delay after each
+func collect[T any](ch <-chan T, delay time.Duration, wg *sync.WaitGroup, out *[]T) {
+	defer wg.Done()
+	for event := range ch {
+		*out = append(*out, event)
+		time.Sleep(delay)
+	}
+}
+
+func main() {
+	const events = 100
+	bus := NewEventBus[int](10, DropNewest)
+
+	var wg sync.WaitGroup
+	var fastA, fastB, slow []int
+	wg.Add(3)
+	go collect(bus.Subscribe(), 0, &wg, &fastA)
+	go collect(bus.Subscribe(), 0, &wg, &fastB)
+	go collect(bus.Subscribe(), 50*time.Millisecond, &wg, &slow)
+
+	start := time.Now()
+	for i := 1; i <= events; i++ {
+		bus.Publish(i)
+		time.Sleep(100 * time.Microsecond) // give the fast subscribers time to keep up
+	}
+	elapsed := time.Since(start)
+	bus.Close()
+	wg.Wait()
+
+	// The slow subscriber takes 50ms per event, so had Publish waited on it
+	// publishing would have taken seconds
+	fmt.Printf("Published %d events in %v\n", events, elapsed.Round(time.Millisecond))
+	fmt.Println("Fast subscriber A received:", len(fastA), "B received:", len(fastB))
+	fmt.Println("Slow subscriber received:", len(slow), "first:", slow[:3], "dropped:", bus.Dropped())
+
+	// With DropOldest a lagging subscriber keeps the most recent events
+	latest := NewEventBus[int](3, DropOldest)
+	ch := latest.Subscribe()
+	for i := 1; i <= 10; i++ {
+		latest.Publish(i)
+	}
+	latest.Close()
+	var kept []int
+	for event := range ch {
+		kept = append(kept, event)
+	}
+	fmt.Println("DropOldest kept:", kept, "dropped:", latest.Dropped()) // [8 9 10] 7
+
+	// Unsubscribing closes the channel; a closed bus hands out closed channels
+	other := NewEventBus[string](1, DropNewest)
+	sub := other.Subscribe()
+	other.Unsubscribe(sub)
+	_, open := <-sub
+	fmt.Println("Open after Unsubscribe:", open)
+	other.Close()
+	other.Close()
+	_, open = <-other.Subscribe()
+	fmt.Println("Open after subscribing to a closed bus:", open)
+}
@@ -0,0 +1,114 @@
+package main
+
+import (
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+// drain reads ch until it is closed
+func drain[T any](ch <-chan T) []T {
+	var events []T
+	for event := range ch {
+		events = append(events, event)
+	}
+	return events
+}
+
+func TestEventBusSlowSubscriberDoesNotBlockOthers(t *testing.T) {
+	const events, buffer = 20, 5
+	bus := NewEventBus[int](buffer, DropNewest)
+
+	// Two fast subscribers acknowledge every event; the slow one reads
+	// nothing until the bus is closed
+	fast := []<-chan int{bus.Subscribe(), bus.Subscribe()}
+	slow := bus.Subscribe()
+	acks := make(chan int)
+	received := make([][]int, len(fast))
+	var readers sync.WaitGroup
+	for i, ch := range fast {
+		readers.Add(1)
+		go func(i int, ch <-chan int) {
+			defer readers.Done()
+			for event := range ch {
+				received[i] = append(received[i], event)
+				acks <- event
+			}
+		}(i, ch)
+	}
+
+	published := make(chan struct{})
+	go func() {
+		defer close(published)
+		for i := 1; i <= events; i++ {
+			bus.Publish(i)
+			<-acks // wait until both fast subscribers have the event
+			<-acks
+		}
+	}()
+	select {
+	case <-published:
+	case <-time.After(time.Second):
+		t.Fatal("Publish blocked on the slow subscriber")
+	}
+	bus.Close()
+	readers.Wait()
+
+	want := make([]int, events)
+	for i := range want {
+		want[i] = i + 1
+	}
+	for i, got := range received {
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("fast subscriber %d received %v, want %v", i, got, want)
+		}
+	}
+	if got := drain(slow); !reflect.DeepEqual(got, want[:buffer]) {
+		t.Errorf("slow subscriber received %v, want the first %d events", got, buffer)
+	}
+	if got := bus.Dropped(); got != events-buffer {
+		t.Errorf("Dropped() = %d, want %d", got, events-buffer)
+	}
+}
+
+func TestEventBusDropOldestKeepsLatest(t *testing.T) {
+	bus := NewEventBus[int](3, DropOldest)
+	ch := bus.Subscribe()
+	for i := 1; i <= 10; i++ {
+		bus.Publish(i)
+	}
+	bus.Close()
+	if got := drain(ch); !reflect.DeepEqual(got, []int{8, 9, 10}) {
+		t.Errorf("DropOldest kept %v, want [8 9 10]", got)
+	}
+	if got := bus.Dropped(); got != 7 {
+		t.Errorf("Dropped() = %d, want 7", got)
+	}
+}
+
+func TestEventBusUnsubscribeAndClose(t *testing.T) {
+	bus := NewEventBus[string](1, DropNewest)
+	gone := bus.Subscribe()
+	stays := bus.Subscribe()
+	bus.Unsubscribe(gone)
+	bus.Unsubscribe(gone) // unknown by now, ignored
+	if _, open := <-gone; open {
+		t.Error("channel still open after Unsubscribe")
+	}
+
+	bus.Publish("hello")
+	if got := <-stays; got != "hello" {
+		t.Errorf("remaining subscriber got %q, want hello", got)
+	}
+
+	bus.Close()
+	bus.Close()
+	bus.Publish("ignored")
+	if _, open := <-stays; open {
+		t.Error("subscriber channel still open after Close")
+	}
+	if _, open := <-bus.Subscribe(); open {
+		t.Error("Subscribe on a closed bus returned an open channel")
+	}
+}
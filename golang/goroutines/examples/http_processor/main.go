@@ -176,6 +176,42 @@ func (hp *HTTPProcessor) Process(ctx context.Context) error {
 	return nil
 }
 
+// ProcessAll fetches every configured URL with at most Workers requests in
+// flight and returns one Result per URL, in the same order as config.URLs.
+// A failed request is reported in its Result and doesn't stop the others. The
+// returned error is the first hard error, the context being cancelled or
+// hitting ProcessTimeout; the results are still returned, and URLs that were
+// never fetched carry that error.
+func (hp *HTTPProcessor) ProcessAll(ctx context.Context) ([]Result, error) {
+	ctx, cancel := context.WithTimeout(ctx, hp.config.ProcessTimeout)
+	defer cancel()
+
+	results := make([]Result, len(hp.config.URLs))
+	workers := max(hp.config.Workers, 1)
+	sem := make(chan struct{}, workers)
+	var wg sync.WaitGroup
+
+	for i, url := range hp.config.URLs {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			for j := i; j < len(results); j++ {
+				results[j] = Result{URL: hp.config.URLs[j], Error: ctx.Err()}
+			}
+			wg.Wait()
+			return results, ctx.Err()
+		}
+		wg.Add(1)
+		go func(i int, url string) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			results[i] = hp.processRequest(ctx, Task{URL: url, ID: i})
+		}(i, url)
+	}
+	wg.Wait()
+	return results, ctx.Err()
+}
+
 func main() {
 	// Set up logging
 	log.SetFlags(log.Ltime | log.Lmicroseconds)
@@ -0,0 +1,110 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// testConfig points the processor at urls with short timeouts
+func testConfig(workers int, urls ...string) Config {
+	config := DefaultConfig
+	config.URLs = urls
+	config.Workers = workers
+	config.RequestTimeout = 2 * time.Second
+	config.ProcessTimeout = 5 * time.Second
+	return config
+}
+
+func TestProcessAllPerURLResults(t *testing.T) {
+	ok := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer ok.Close()
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer failing.Close()
+	unreachable := httptest.NewServer(http.NotFoundHandler())
+	unreachable.Close() // nothing listens on this address any more
+
+	urls := []string{ok.URL, failing.URL, unreachable.URL, ok.URL + "/again"}
+	results, err := NewHTTPProcessor(testConfig(2, urls...)).ProcessAll(context.Background())
+	if err != nil {
+		t.Fatalf("ProcessAll error = %v, want nil", err)
+	}
+	if len(results) != len(urls) {
+		t.Fatalf("got %d results, want %d", len(results), len(urls))
+	}
+
+	tests := []struct {
+		status  string
+		wantErr bool
+	}{
+		{status: "200 OK"},
+		{status: "500 Internal Server Error"}, // a response, not a request error
+		{wantErr: true},
+		{status: "200 OK"},
+	}
+	for i, tt := range tests {
+		result := results[i]
+		if result.URL != urls[i] {
+			t.Errorf("results[%d].URL = %q, want %q", i, result.URL, urls[i])
+		}
+		if result.Status != tt.status {
+			t.Errorf("%s: Status = %q, want %q", urls[i], result.Status, tt.status)
+		}
+		if (result.Error != nil) != tt.wantErr {
+			t.Errorf("%s: Error = %v, want error: %v", urls[i], result.Error, tt.wantErr)
+		}
+	}
+}
+
+func TestProcessAllBoundsWorkers(t *testing.T) {
+	const workers = 2
+	var inFlight, peak atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+	}))
+	defer server.Close()
+
+	urls := make([]string, 8)
+	for i := range urls {
+		urls[i] = server.URL
+	}
+	if _, err := NewHTTPProcessor(testConfig(workers, urls...)).ProcessAll(context.Background()); err != nil {
+		t.Fatalf("ProcessAll error = %v, want nil", err)
+	}
+	if got := peak.Load(); got > workers {
+		t.Errorf("peak in-flight requests = %d, want at most %d", got, workers)
+	}
+}
+
+func TestProcessAllCancelledContext(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	results, err := NewHTTPProcessor(testConfig(1, server.URL, server.URL)).ProcessAll(ctx)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("ProcessAll error = %v, want context.Canceled", err)
+	}
+	for i, result := range results {
+		if result.Error == nil {
+			t.Errorf("results[%d].Error = nil, want the cancellation", i)
+		}
+	}
+}
@@ -0,0 +1,77 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// Backend is a server with a relative weight; a weight of 2 gets twice the
+// traffic of a weight of 1, and a weight of 0 or less gets none
+type Backend struct {
+	Name   string
+	Weight int
+}
+
+type wrrBackend struct {
+	Backend
+	current int
+}
+
+// WeightedRoundRobin picks backends using nginx's smooth weighted round-robin.
+// Naive weighting sends a backend of weight 5 five requests in a row; the
+// smooth version interleaves the others in between. On every pick each
+// backend's current score grows by its weight, the highest score wins, and
+// the winner's score drops by the total weight. Over any run of total-weight
+// picks each backend is chosen exactly weight times. It is safe for
+// concurrent use.
+type WeightedRoundRobin struct {
+	mu          sync.Mutex
+	backends    []*wrrBackend
+	totalWeight int
+}
+
+// NewWeightedRoundRobin creates a balancer over backends, skipping any
+// without a positive weight. Ties are broken by order in backends.
+func NewWeightedRoundRobin(backends []Backend) *WeightedRoundRobin {
+	w := &WeightedRoundRobin{}
+	for _, b := range backends {
+		if b.Weight <= 0 {
+			continue
+		}
+		w.backends = append(w.backends, &wrrBackend{Backend: b})
+		w.totalWeight += b.Weight
+	}
+	return w
+}
+
+// Next returns the name of the next backend, or "" if there are none
+func (w *WeightedRoundRobin) Next() string {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	var best *wrrBackend
+	for _, b := range w.backends {
+		b.current += b.Weight
+		if best == nil || b.current > best.current {
+			best = b
+		}
+	}
+	if best == nil {
+		return ""
+	}
+	best.current -= w.totalWeight
+	return best.Name
+}
+
+func main() {
+	lb := NewWeightedRoundRobin([]Backend{{"a", 5}, {"b", 1}, {"c", 1}, {"drained", 0}})
+
+	// One full cycle is the sum of the weights. Naive repetition would give
+	// a a a a a b c; the smooth order spreads b and c between the a's.
+	picks := make([]string, 7)
+	for i := range picks {
+		picks[i] = lb.Next()
+	}
+	fmt.Println("One cycle:", strings.Join(picks, " ")) // a a b a c a a
+}
@@ -0,0 +1,114 @@
+package main
+
+import (
+	"reflect"
+	"sync"
+	"testing"
+)
+
+// cycle returns the next n picks
+func cycle(w *WeightedRoundRobin, n int) []string {
+	picks := make([]string, n)
+	for i := range picks {
+		picks[i] = w.Next()
+	}
+	return picks
+}
+
+// longestRun returns the most times one backend is picked back to back
+func longestRun(picks []string) int {
+	longest, run := 0, 0
+	for i := range picks {
+		if i > 0 && picks[i] == picks[i-1] {
+			run++
+		} else {
+			run = 1
+		}
+		longest = max(longest, run)
+	}
+	return longest
+}
+
+// pickCounts counts how often each backend appears in picks
+func pickCounts(picks []string) map[string]int {
+	counts := make(map[string]int)
+	for _, p := range picks {
+		counts[p]++
+	}
+	return counts
+}
+
+func TestWeightedRoundRobinCycleCounts(t *testing.T) {
+	tests := []struct {
+		name     string
+		backends []Backend
+		want     map[string]int // picks per cycle; a cycle is the sum of the weights
+	}{
+		{name: "skewed", backends: []Backend{{"a", 5}, {"b", 1}, {"c", 1}}, want: map[string]int{"a": 5, "b": 1, "c": 1}},
+		{name: "equal", backends: []Backend{{"a", 1}, {"b", 1}, {"c", 1}}, want: map[string]int{"a": 1, "b": 1, "c": 1}},
+		{name: "mixed", backends: []Backend{{"x", 3}, {"y", 2}, {"z", 4}}, want: map[string]int{"x": 3, "y": 2, "z": 4}},
+		{name: "zero and negative weights", backends: []Backend{{"a", 2}, {"off", 0}, {"b", 1}, {"bad", -3}}, want: map[string]int{"a": 2, "b": 1}},
+		{name: "single", backends: []Backend{{"only", 3}}, want: map[string]int{"only": 3}},
+	}
+	for _, tt := range tests {
+		lb := NewWeightedRoundRobin(tt.backends)
+		total := 0
+		for _, n := range tt.want {
+			total += n
+		}
+		// Every cycle, not just the first, has the exact counts
+		for c := 0; c < 3; c++ {
+			if got := pickCounts(cycle(lb, total)); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("%s: cycle %d counts = %v, want %v", tt.name, c, got, tt.want)
+			}
+		}
+	}
+}
+
+func TestWeightedRoundRobinIsSmooth(t *testing.T) {
+	lb := NewWeightedRoundRobin([]Backend{{"a", 5}, {"b", 1}, {"c", 1}})
+	picks := cycle(lb, 7)
+	if want := []string{"a", "a", "b", "a", "c", "a", "a"}; !reflect.DeepEqual(picks, want) {
+		t.Errorf("one cycle = %v, want %v", picks, want)
+	}
+	// Naive repetition would give a run of 5
+	if got := longestRun(picks); got > 2 {
+		t.Errorf("longest run of one backend = %d, want at most 2", got)
+	}
+	if next := cycle(lb, 7); !reflect.DeepEqual(next, picks) {
+		t.Errorf("next cycle = %v, want %v", next, picks)
+	}
+}
+
+func TestWeightedRoundRobinNoBackends(t *testing.T) {
+	for _, backends := range [][]Backend{nil, {{"off", 0}}} {
+		if got := NewWeightedRoundRobin(backends).Next(); got != "" {
+			t.Errorf("Next() with backends %v = %q, want empty", backends, got)
+		}
+	}
+}
+
+// Run with -race: concurrent callers still split traffic exactly by weight,
+// since 1000 picks are 200 whole cycles
+func TestWeightedRoundRobinConcurrent(t *testing.T) {
+	lb := NewWeightedRoundRobin([]Backend{{"x", 3}, {"y", 2}})
+	var mu sync.Mutex
+	counts := make(map[string]int)
+	var wg sync.WaitGroup
+	for g := 0; g < 10; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 100; i++ {
+				name := lb.Next()
+				mu.Lock()
+				counts[name]++
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+	if want := map[string]int{"x": 600, "y": 400}; !reflect.DeepEqual(counts, want) {
+		t.Errorf("1000 concurrent picks = %v, want %v", counts, want)
+	}
+}
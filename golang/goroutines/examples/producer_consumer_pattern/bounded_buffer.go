@@ -0,0 +1,91 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// BoundedBuffer is a fixed-capacity FIFO queue that lets each producer pick
+// its backpressure policy per call: Put waits for space, TryPut drops the item
+// instead. It is safe for concurrent use.
+type BoundedBuffer[T any] struct {
+	items chan T
+}
+
+// NewBoundedBuffer creates a buffer holding at most capacity items
+func NewBoundedBuffer[T any](capacity int) *BoundedBuffer[T] {
+	return &BoundedBuffer[T]{items: make(chan T, capacity)}
+}
+
+// Put adds item, blocking while the buffer is full. It returns ctx.Err() if
+// ctx is done before there is space, in which case item was not added.
+func (b *BoundedBuffer[T]) Put(ctx context.Context, item T) error {
+	// Checked first so a cancelled context always wins, even with space free
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	select {
+	case b.items <- item:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// TryPut adds item only if there is space right now, reporting whether it did
+func (b *BoundedBuffer[T]) TryPut(item T) bool {
+	select {
+	case b.items <- item:
+		return true
+	default:
+		return false
+	}
+}
+
+// Get removes and returns the oldest item, blocking while the buffer is
+// empty. It returns ctx.Err() if ctx is done before an item arrives.
+func (b *BoundedBuffer[T]) Get(ctx context.Context) (T, error) {
+	var zero T
+	if err := ctx.Err(); err != nil {
+		return zero, err
+	}
+	select {
+	case item := <-b.items:
+		return item, nil
+	case <-ctx.Done():
+		return zero, ctx.Err()
+	}
+}
+
+// Len returns the number of buffered items
+func (b *BoundedBuffer[T]) Len() int {
+	return len(b.items)
+}
+
+// Cap returns the buffer's capacity
+func (b *BoundedBuffer[T]) Cap() int {
+	return cap(b.items)
+}
+
+func main() {
+	ctx := context.Background()
+	buffer := NewBoundedBuffer[int](2)
+
+	fmt.Println("TryPut 1:", buffer.TryPut(1))                                        // true
+	fmt.Println("TryPut 2:", buffer.TryPut(2))                                        // true
+	fmt.Println("TryPut 3 on a full buffer:", buffer.TryPut(3), "len:", buffer.Len()) // false 2
+
+	// A blocking Put on the full buffer waits until a Get frees a slot
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		got, _ := buffer.Get(ctx)
+		fmt.Println("Get returned", got)
+	}()
+	fmt.Println("Put 3 after waiting:", buffer.Put(ctx, 3)) // <nil>
+
+	// Both blocking calls give up when their context ends
+	timeoutCtx, cancel := context.WithTimeout(ctx, 20*time.Millisecond)
+	defer cancel()
+	fmt.Println("Put on a full buffer:", buffer.Put(timeoutCtx, 4)) // context deadline exceeded
+}
@@ -0,0 +1,95 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestBoundedBufferTryPut(t *testing.T) {
+	buffer := NewBoundedBuffer[int](2)
+	if !buffer.TryPut(1) || !buffer.TryPut(2) {
+		t.Fatal("TryPut into a buffer with space = false, want true")
+	}
+	if buffer.TryPut(3) {
+		t.Error("TryPut into a full buffer = true, want false")
+	}
+	if buffer.Len() != 2 || buffer.Cap() != 2 {
+		t.Errorf("Len(), Cap() = %d, %d, want 2, 2", buffer.Len(), buffer.Cap())
+	}
+}
+
+func TestBoundedBufferPutUnblocksAfterGet(t *testing.T) {
+	ctx := context.Background()
+	buffer := NewBoundedBuffer[int](2)
+	buffer.TryPut(1)
+	buffer.TryPut(2)
+
+	putDone := make(chan error)
+	go func() { putDone <- buffer.Put(ctx, 3) }()
+
+	select {
+	case err := <-putDone:
+		t.Fatalf("Put on a full buffer returned %v without waiting", err)
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	if got, err := buffer.Get(ctx); got != 1 || err != nil {
+		t.Fatalf("Get() = %d, %v, want 1, nil", got, err)
+	}
+	select {
+	case err := <-putDone:
+		if err != nil {
+			t.Fatalf("Put after Get freed a slot = %v, want nil", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Put still blocked after Get freed a slot")
+	}
+
+	// Items come out in FIFO order, including the one Put waited to add
+	for _, want := range []int{2, 3} {
+		if got, err := buffer.Get(ctx); got != want || err != nil {
+			t.Errorf("Get() = %d, %v, want %d, nil", got, err, want)
+		}
+	}
+}
+
+func TestBoundedBufferGetUnblocksAfterPut(t *testing.T) {
+	ctx := context.Background()
+	buffer := NewBoundedBuffer[string](1)
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+		buffer.Put(ctx, "late")
+	}()
+	if got, err := buffer.Get(ctx); got != "late" || err != nil {
+		t.Errorf("Get() = %q, %v, want late, nil", got, err)
+	}
+}
+
+func TestBoundedBufferContextEnds(t *testing.T) {
+	buffer := NewBoundedBuffer[int](1)
+
+	timeoutCtx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if _, err := buffer.Get(timeoutCtx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Get on an empty buffer = %v, want context.DeadlineExceeded", err)
+	}
+
+	// A cancelled context wins even when there is space to Put or an item to Get
+	cancelled, cancelNow := context.WithCancel(context.Background())
+	cancelNow()
+	if err := buffer.Put(cancelled, 1); !errors.Is(err, context.Canceled) || buffer.Len() != 0 {
+		t.Errorf("Put with a cancelled ctx = %v, len %d, want context.Canceled, len 0", err, buffer.Len())
+	}
+	buffer.TryPut(1)
+	if _, err := buffer.Get(cancelled); !errors.Is(err, context.Canceled) || buffer.Len() != 1 {
+		t.Errorf("Get with a cancelled ctx = %v, len %d, want context.Canceled, len 1", err, buffer.Len())
+	}
+
+	timeoutCtx, cancel = context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if err := buffer.Put(timeoutCtx, 2); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Put on a full buffer = %v, want context.DeadlineExceeded", err)
+	}
+}
@@ -1,6 +1,8 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"math/rand"
 	"sync"
@@ -14,26 +16,77 @@ type Message struct {
 	Timestamp time.Time
 }
 
+// ConsumerFunc processes one message; a non-nil error marks the attempt as failed
+type ConsumerFunc func(Message) error
+
+// ConsumerError describes a message that still failed after every retry
+type ConsumerError struct {
+	ConsumerID int
+	Message    Message
+	Attempts   int
+	Err        error
+}
+
+func (e ConsumerError) Error() string {
+	return fmt.Sprintf("consumer-%d: message %d failed after %d attempts: %v",
+		e.ConsumerID, e.Message.ID, e.Attempts, e.Err)
+}
+
+func (e ConsumerError) Unwrap() error {
+	return e.Err
+}
+
 // Buffer represents a thread-safe message queue with separate producer and consumer management
 type Buffer struct {
 	messages         chan Message
+	done             chan struct{} // closed by Shutdown to stop producers
+	shutdownOnce     sync.Once
+	closeOnce        sync.Once
 	producerWg       sync.WaitGroup
 	consumerWg       sync.WaitGroup
 	producersRunning int
+	stopped          bool // no new producers may start
 	mutex            sync.Mutex
+	maxAttempts      int                // attempts per message before dead-lettering
+	failures         chan ConsumerError // failures reported to the orchestrator
+	failuresOnce     sync.Once
+	deadLetters      []Message
+	deadLetterMutex  sync.Mutex
 }
 
-// NewBuffer creates a new buffer with specified capacity
-func NewBuffer(capacity int) *Buffer {
+// NewBuffer creates a new buffer with specified capacity. Consumers retry a
+// failing message up to maxRetries times before moving it to the dead
+// letters; a negative maxRetries is treated as zero.
+func NewBuffer(capacity int, maxRetries int) *Buffer {
+	if maxRetries < 0 {
+		maxRetries = 0
+	}
 	return &Buffer{
-		messages: make(chan Message, capacity),
+		messages:    make(chan Message, capacity),
+		done:        make(chan struct{}),
+		maxAttempts: maxRetries + 1,
+		failures:    make(chan ConsumerError, capacity),
 	}
 }
 
-// StartProducer adds and starts a new producer
-func (b *Buffer) StartProducer(id int, producerMessageCount int) {
-	b.producerWg.Add(1)
+// closeMessages closes the message channel exactly once, however many
+// producers finish or Shutdown calls race to do it
+func (b *Buffer) closeMessages() {
+	b.closeOnce.Do(func() {
+		close(b.messages)
+	})
+}
+
+// StartProducer adds and starts a new producer. The producer stops early when
+// ctx is cancelled or Shutdown is called. Producers started after shutdown,
+// or after the last producer has finished, are ignored.
+func (b *Buffer) StartProducer(ctx context.Context, id int, producerMessageCount int) {
 	b.mutex.Lock()
+	if b.stopped {
+		b.mutex.Unlock()
+		return
+	}
+	b.producerWg.Add(1)
 	b.producersRunning++
 	b.mutex.Unlock()
 
@@ -43,7 +96,9 @@ func (b *Buffer) StartProducer(id int, producerMessageCount int) {
 			b.mutex.Lock()
 			b.producersRunning--
 			if b.producersRunning == 0 {
-				close(b.messages)
+				// Only the last producer closes, so nobody can send on a closed channel
+				b.stopped = true
+				b.closeMessages()
 			}
 			b.mutex.Unlock()
 		}()
@@ -54,25 +109,55 @@ func (b *Buffer) StartProducer(id int, producerMessageCount int) {
 				Data:      fmt.Sprintf("Message from Producer-%d", id),
 				Timestamp: time.Now(),
 			}
-			b.messages <- msg
+			select {
+			case b.messages <- msg:
+			case <-ctx.Done():
+				return
+			case <-b.done:
+				return
+			}
 			fmt.Printf("Producer-%d produced message %d at %v\n",
 				id, i, msg.Timestamp.Format(time.StampMilli))
 
 			// Simulate fast production (100-300ms)
-			time.Sleep(time.Duration(rand.Intn(200)+100) * time.Millisecond)
+			select {
+			case <-time.After(time.Duration(rand.Intn(200)+100) * time.Millisecond):
+			case <-ctx.Done():
+				return
+			case <-b.done:
+				return
+			}
 		}
 	}()
 }
 
-// StartConsumer adds and starts a new consumer
-func (b *Buffer) StartConsumer(id int) {
+// StartConsumer adds and starts a new consumer running handle on every
+// message. A nil handle simulates slow processing that always succeeds.
+// The consumer drains the buffer until it is closed, or stops immediately
+// when ctx is cancelled.
+func (b *Buffer) StartConsumer(ctx context.Context, id int, handle ConsumerFunc) {
+	if handle == nil {
+		handle = simulateProcessing
+	}
 	b.consumerWg.Add(1)
 	go func() {
 		defer b.consumerWg.Done()
-		for msg := range b.messages {
-			// Simulate slower processing (300-700ms)
-			processingTime := time.Duration(rand.Intn(400)+300) * time.Millisecond
-			time.Sleep(processingTime)
+		for {
+			var msg Message
+			select {
+			case m, ok := <-b.messages:
+				if !ok {
+					return
+				}
+				msg = m
+			case <-ctx.Done():
+				return
+			}
+
+			if err := b.handleWithRetry(ctx, id, msg, handle); err != nil {
+				fmt.Printf("Consumer-%d gave up on message %d: %v\n", id, msg.ID, err)
+				continue
+			}
 
 			latency := time.Since(msg.Timestamp)
 			fmt.Printf("Consumer-%d processed message %d after %v latency\n",
@@ -81,36 +166,129 @@ func (b *Buffer) StartConsumer(id int) {
 	}()
 }
 
+// handleWithRetry runs handle until it succeeds or the attempts run out, in
+// which case the message is dead-lettered and reported on the error channel
+func (b *Buffer) handleWithRetry(ctx context.Context, id int, msg Message, handle ConsumerFunc) error {
+	var err error
+	attempts := 0
+	for attempts < b.maxAttempts {
+		attempts++
+		if err = handle(msg); err == nil {
+			return nil
+		}
+		if ctx.Err() != nil {
+			break
+		}
+	}
+
+	b.deadLetterMutex.Lock()
+	b.deadLetters = append(b.deadLetters, msg)
+	b.deadLetterMutex.Unlock()
+
+	failure := ConsumerError{ConsumerID: id, Message: msg, Attempts: attempts, Err: err}
+	select {
+	case b.failures <- failure:
+	default:
+		// Nobody is draining Errors fast enough; the dead letters still have it
+	}
+	return failure
+}
+
+// simulateProcessing is the default ConsumerFunc (300-700ms of work)
+func simulateProcessing(msg Message) error {
+	time.Sleep(time.Duration(rand.Intn(400)+300) * time.Millisecond)
+	return nil
+}
+
+// Errors returns the channel on which consumers report messages that failed
+// every attempt. It is closed by WaitForConsumers.
+func (b *Buffer) Errors() <-chan ConsumerError {
+	return b.failures
+}
+
+// DeadLetters returns the messages that failed every attempt. Call it after
+// WaitForConsumers to get the complete list.
+func (b *Buffer) DeadLetters() []Message {
+	b.deadLetterMutex.Lock()
+	defer b.deadLetterMutex.Unlock()
+	return append([]Message(nil), b.deadLetters...)
+}
+
+// Shutdown stops all producers. Messages already in the buffer are still
+// delivered to consumers, which exit once the buffer is drained. It is safe
+// to call Shutdown more than once and concurrently with running producers.
+func (b *Buffer) Shutdown() {
+	b.shutdownOnce.Do(func() {
+		close(b.done)
+	})
+
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	b.stopped = true
+	if b.producersRunning == 0 {
+		b.closeMessages()
+	}
+}
+
 // WaitForProducers waits for all producers to finish
 func (b *Buffer) WaitForProducers() {
 	b.producerWg.Wait()
 }
 
-// WaitForConsumers waits for all consumers to finish
+// WaitForConsumers waits for all consumers to finish and then closes the
+// Errors channel
 func (b *Buffer) WaitForConsumers() {
 	b.consumerWg.Wait()
+	b.failuresOnce.Do(func() {
+		close(b.failures)
+	})
 }
 
 func main() {
 	// Seed the random number generator
 	rand.Seed(time.Now().UnixNano())
 
-	// Create a buffer with capacity of 50 messages
-	buffer := NewBuffer(50)
+	ctx := context.Background()
+
+	// Create a buffer with capacity of 50 messages, retrying failures twice
+	buffer := NewBuffer(50, 2)
 
 	// Start multiple producers
 	numProducers := 3
 	messagesPerProducer := 5
 	for i := 0; i < numProducers; i++ {
-		buffer.StartProducer(i, messagesPerProducer)
+		buffer.StartProducer(ctx, i, messagesPerProducer)
 	}
 
 	// Start multiple consumers
+	// Every consumer fails on message 3, so those messages end up dead-lettered
+	errPoisonMessage := errors.New("poison message")
+	handle := func(msg Message) error {
+		if msg.ID == 3 {
+			return errPoisonMessage
+		}
+		return simulateProcessing(msg)
+	}
 	numConsumers := 2
 	for i := 0; i < numConsumers; i++ {
-		buffer.StartConsumer(i)
+		buffer.StartConsumer(ctx, i, handle)
 	}
 
+	// The orchestrator logs failures as they are reported
+	logged := make(chan struct{})
+	go func() {
+		defer close(logged)
+		for failure := range buffer.Errors() {
+			fmt.Printf("Orchestrator: %v\n", failure)
+		}
+	}()
+
+	// Stop production early; consumers still drain what was produced
+	time.AfterFunc(700*time.Millisecond, func() {
+		fmt.Println("Shutting down producers...")
+		buffer.Shutdown()
+	})
+
 	// Wait for all producers to finish
 	buffer.WaitForProducers()
 
@@ -118,6 +296,11 @@ func main() {
 	// This ensures all messages have been properly handled before program exit
 	// Without this, the program might terminate while consumers are still processing
 	buffer.WaitForConsumers()
+	<-logged
+
+	for _, msg := range buffer.DeadLetters() {
+		fmt.Printf("Dead letter: message %d (%s)\n", msg.ID, msg.Data)
+	}
 
 	fmt.Println("All processing completed!")
 }
@@ -0,0 +1,162 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// waitOrFail fails the test if wait does not return within timeout
+func waitOrFail(t *testing.T, name string, timeout time.Duration, wait func()) {
+	t.Helper()
+	done := make(chan struct{})
+	go func() {
+		wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(timeout):
+		t.Fatalf("%s did not return within %v", name, timeout)
+	}
+}
+
+// instant is a ConsumerFunc that succeeds without simulating work
+func instant(Message) error { return nil }
+
+func TestBufferShutdownMidStream(t *testing.T) {
+	// A panic such as "send on closed channel" in any producer goroutine
+	// crashes the test binary, so reaching the end is the assertion
+	buffer := NewBuffer(1, 0)
+	var consumed atomic.Int32
+	for i := 0; i < 4; i++ {
+		buffer.StartProducer(context.Background(), i, 1000)
+	}
+	for i := 0; i < 2; i++ {
+		buffer.StartConsumer(context.Background(), i, func(Message) error {
+			consumed.Add(1)
+			return nil
+		})
+	}
+
+	time.Sleep(150 * time.Millisecond)
+	// Racing Shutdown calls must close the channel only once
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			buffer.Shutdown()
+		}()
+	}
+	wg.Wait()
+
+	waitOrFail(t, "WaitForProducers", 2*time.Second, buffer.WaitForProducers)
+	waitOrFail(t, "WaitForConsumers", 2*time.Second, buffer.WaitForConsumers)
+	if n := consumed.Load(); n == 0 || n >= 4000 {
+		t.Errorf("consumed %d messages, want some but not all of 4000", n)
+	}
+
+	// Producers started after shutdown are ignored
+	buffer.StartProducer(context.Background(), 99, 10)
+	waitOrFail(t, "WaitForProducers after shutdown", time.Second, buffer.WaitForProducers)
+}
+
+func TestBufferShutdownBeforeProducers(t *testing.T) {
+	buffer := NewBuffer(4, 0)
+	buffer.StartConsumer(context.Background(), 0, instant)
+	buffer.Shutdown()
+	buffer.StartProducer(context.Background(), 0, 10)
+	waitOrFail(t, "WaitForConsumers", time.Second, buffer.WaitForConsumers)
+}
+
+func TestBufferContextCancel(t *testing.T) {
+	buffer := NewBuffer(1, 0)
+	ctx, cancel := context.WithCancel(context.Background())
+	buffer.StartProducer(ctx, 0, 1000)
+	buffer.StartConsumer(ctx, 0, instant)
+
+	time.Sleep(50 * time.Millisecond)
+	cancel()
+	waitOrFail(t, "WaitForProducers", 2*time.Second, buffer.WaitForProducers)
+	waitOrFail(t, "WaitForConsumers", 2*time.Second, buffer.WaitForConsumers)
+}
+
+func TestBufferDrainsAfterProducersFinish(t *testing.T) {
+	buffer := NewBuffer(10, 0)
+	var consumed atomic.Int32
+	buffer.StartProducer(context.Background(), 0, 2)
+	buffer.StartConsumer(context.Background(), 0, func(Message) error {
+		consumed.Add(1)
+		return nil
+	})
+	waitOrFail(t, "WaitForProducers", 2*time.Second, buffer.WaitForProducers)
+	waitOrFail(t, "WaitForConsumers", 2*time.Second, buffer.WaitForConsumers)
+	if n := consumed.Load(); n != 2 {
+		t.Errorf("consumed %d messages, want 2", n)
+	}
+}
+
+func TestBufferDeadLettersPoisonMessage(t *testing.T) {
+	errPoison := errors.New("poison message")
+	var calls atomic.Int32
+	handle := func(msg Message) error {
+		if msg.ID == 1 {
+			calls.Add(1)
+			return errPoison
+		}
+		return nil
+	}
+
+	const producers, retries = 2, 2
+	buffer := NewBuffer(10, retries)
+	for i := 0; i < producers; i++ {
+		buffer.StartProducer(context.Background(), i, 3)
+	}
+	for i := 0; i < 3; i++ {
+		buffer.StartConsumer(context.Background(), i, handle)
+	}
+
+	var failures []ConsumerError
+	collected := make(chan struct{})
+	go func() {
+		defer close(collected)
+		for failure := range buffer.Errors() {
+			failures = append(failures, failure)
+		}
+	}()
+	waitOrFail(t, "WaitForProducers", 5*time.Second, buffer.WaitForProducers)
+	waitOrFail(t, "WaitForConsumers", 5*time.Second, buffer.WaitForConsumers)
+	<-collected
+
+	// Every producer sends a message 1 and every one of them fails
+	deadLetters := buffer.DeadLetters()
+	if len(deadLetters) != producers {
+		t.Fatalf("got %d dead letters, want %d", len(deadLetters), producers)
+	}
+	for _, msg := range deadLetters {
+		if msg.ID != 1 {
+			t.Errorf("dead letter has ID %d, want 1", msg.ID)
+		}
+	}
+	if len(failures) != producers {
+		t.Fatalf("got %d reported failures, want %d", len(failures), producers)
+	}
+	for _, failure := range failures {
+		if failure.Attempts != retries+1 || !errors.Is(failure, errPoison) {
+			t.Errorf("failure = %v, want %d attempts wrapping %v", failure, retries+1, errPoison)
+		}
+	}
+	if got, want := calls.Load(), int32(producers*(retries+1)); got != want {
+		t.Errorf("handler called %d times for the poison messages, want %d", got, want)
+	}
+}
+
+func TestNewBufferNegativeRetries(t *testing.T) {
+	if b := NewBuffer(1, -3); b.maxAttempts != 1 {
+		t.Errorf("maxAttempts = %d, want 1", b.maxAttempts)
+	}
+}
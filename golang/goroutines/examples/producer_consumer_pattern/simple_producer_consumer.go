@@ -2,7 +2,7 @@ package main
 
 import (
 	"fmt"
-	"time"
+	"sync"
 )
 
 // ConsumerProducer is an interface that defines methods for producing and consuming tasks
@@ -17,26 +17,69 @@ type Task struct {
 	Data string // Data associated with the task
 }
 
-// producer function sends tasks to the provided channel
-func producer(ch chan<- Task) {
-	ch <- Task{ID: 1, Data: "Task 1"} // Send Task 1 to the channel
-	ch <- Task{ID: 2, Data: "Task 2"} // Send Task 2 to the channel
-	ch <- Task{ID: 3, Data: "Task 3"} // Send Task 3 to the channel
+// TaskPipeline produces a fixed set of tasks and records every task it consumes
+type TaskPipeline struct {
+	Tasks []Task // Tasks to send from Produce
+
+	mu       sync.Mutex
+	consumed []Task
+}
+
+// Ensure TaskPipeline satisfies the ConsumerProducer interface
+var _ ConsumerProducer = (*TaskPipeline)(nil)
+
+// Produce sends every task to the channel and closes it, since the
+// producer is the only side that knows no more tasks are coming
+func (p *TaskPipeline) Produce(ch chan<- Task) {
+	defer close(ch)
+	for _, task := range p.Tasks {
+		ch <- task // Send the task to the channel
+	}
 }
 
-// consumer function receives tasks from the provided channel and processes them
-func consumer(ch <-chan Task) {
+// Consume receives tasks until the channel is closed and processes them
+func (p *TaskPipeline) Consume(ch <-chan Task) {
 	for task := range ch { // Continuously receive tasks from the channel
 		fmt.Println(task) // Print the task
+		p.mu.Lock()
+		p.consumed = append(p.consumed, task)
+		p.mu.Unlock()
 	}
 }
 
-func main() {
+// Consumed returns the tasks processed so far
+func (p *TaskPipeline) Consumed() []Task {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return append([]Task(nil), p.consumed...)
+}
+
+// Run wires Produce and Consume over a shared channel and waits until every
+// task has been consumed
+func (p *TaskPipeline) Run() []Task {
 	ch := make(chan Task) // Create a channel for Task communication
 
-	go producer(ch) // Start the producer goroutine
-	go consumer(ch) // Start the consumer goroutine
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		p.Consume(ch) // Start the consumer goroutine
+	}()
 
-	time.Sleep(time.Second * 1) // Allow some time for goroutines to complete
+	p.Produce(ch) // Producer returns once the consumer has received everything
+	wg.Wait()
+	return p.Consumed()
 }
 
+func main() {
+	pipeline := &TaskPipeline{
+		Tasks: []Task{
+			{ID: 1, Data: "Task 1"},
+			{ID: 2, Data: "Task 2"},
+			{ID: 3, Data: "Task 3"},
+		},
+	}
+
+	consumed := pipeline.Run()
+	fmt.Printf("Consumed %d of %d tasks\n", len(consumed), len(pipeline.Tasks))
+}
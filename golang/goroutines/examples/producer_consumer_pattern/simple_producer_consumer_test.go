@@ -0,0 +1,28 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestTaskPipelineConsumesAllTasks(t *testing.T) {
+	var tasks []Task
+	for i := 1; i <= 50; i++ {
+		tasks = append(tasks, Task{ID: i, Data: "payload"})
+	}
+	pipeline := &TaskPipeline{Tasks: tasks}
+
+	consumed := pipeline.Run()
+	if !reflect.DeepEqual(consumed, tasks) {
+		t.Errorf("consumed %d tasks %v, want all %d in order", len(consumed), consumed, len(tasks))
+	}
+	if got := pipeline.Consumed(); len(got) != len(tasks) {
+		t.Errorf("Consumed() has %d tasks, want %d", len(got), len(tasks))
+	}
+}
+
+func TestTaskPipelineNoTasks(t *testing.T) {
+	if consumed := (&TaskPipeline{}).Run(); len(consumed) != 0 {
+		t.Errorf("consumed %v, want nothing", consumed)
+	}
+}
@@ -3,6 +3,9 @@ package main
 import (
 	"fmt"
 	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
 	"time"
 )
 
@@ -12,13 +15,17 @@ type RateLimiter struct {
 	client *http.Client
 }
 
-// NewRateLimiter creates a new rate limiter with specified capacity
-func NewRateLimiter(maxRequests int, refillInterval time.Duration) *RateLimiter {
+// NewRateLimiter creates a new rate limiter with specified capacity.
+// Requests are sent with client; nil uses a client with a 10 second timeout.
+func NewRateLimiter(maxRequests int, refillInterval time.Duration, client *http.Client) *RateLimiter {
+	if client == nil {
+		client = &http.Client{
+			Timeout: 10 * time.Second,
+		}
+	}
 	rl := &RateLimiter{
 		tokens: make(chan struct{}, maxRequests),
-		client: &http.Client{
-			Timeout: 10 * time.Second,
-		},
+		client: client,
 	}
 
 	// Initially fill the token bucket
@@ -53,20 +60,28 @@ func (rl *RateLimiter) MakeRequest(url string) (*http.Response, error) {
 }
 
 func main() {
+	// A local server instead of a real API, so the run is deterministic. It
+	// counts the requests that arrive before the first token refill.
+	start := time.Now()
+	var beforeRefill atomic.Int64
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if time.Since(start) < time.Second {
+			beforeRefill.Add(1)
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
 	// Create a rate limiter: 2 requests per second
-	limiter := NewRateLimiter(2, time.Second)
-
-	// Example URLs to test
-	urls := []string{
-		"https://api.github.com",
-		"https://api.github.com/users",
-		"https://api.github.com/repos",
-		"https://api.github.com/gists",
-	}
+	const maxRequests = 2
+	limiter := NewRateLimiter(maxRequests, time.Second, server.Client())
 
 	// Make multiple requests
-	for i, url := range urls {
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
 		go func(requestID int, requestURL string) {
+			defer wg.Done()
 			fmt.Printf("Request %d waiting for rate limiter...\n", requestID)
 
 			resp, err := limiter.MakeRequest(requestURL)
@@ -78,11 +93,12 @@ func main() {
 
 			fmt.Printf("Request %d completed with status: %s\n",
 				requestID, resp.Status)
-		}(i+1, url)
+		}(i+1, fmt.Sprintf("%s/item/%d", server.URL, i+1))
 	}
 
-	// Wait to see the results
-	time.Sleep(5 * time.Second)
+	wg.Wait()
+	fmt.Printf("Requests before the first refill: %d (bucket size %d, within limit: %v)\n",
+		beforeRefill.Load(), maxRequests, beforeRefill.Load() <= maxRequests)
 }
 
 // Helper function to demonstrate error handling
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRateLimiterBurstIsBucketSize(t *testing.T) {
+	const maxRequests, total = 2, 5
+	const refill = 200 * time.Millisecond
+
+	var inFlight, maxInFlight, arrived atomic.Int64
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		arrived.Add(1)
+		current := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			seen := maxInFlight.Load()
+			if current <= seen || maxInFlight.CompareAndSwap(seen, current) {
+				break
+			}
+		}
+		time.Sleep(refill / 2)
+	}))
+	defer server.Close()
+
+	limiter := NewRateLimiter(maxRequests, refill, server.Client())
+	var wg sync.WaitGroup
+	for i := 0; i < total; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			resp, err := limiter.MakeRequest(server.URL)
+			if err != nil {
+				t.Errorf("MakeRequest: %v", err)
+				return
+			}
+			resp.Body.Close()
+		}()
+	}
+
+	// Before the first refill only the initial tokens can be spent
+	time.Sleep(refill / 2)
+	if got := arrived.Load(); got != maxRequests {
+		t.Errorf("%d requests arrived before the first refill, want %d", got, maxRequests)
+	}
+
+	// The rest go out as tokens are refilled, one per interval
+	wg.Wait()
+	if got := arrived.Load(); got != total {
+		t.Errorf("%d requests arrived in total, want %d", got, total)
+	}
+	if got := maxInFlight.Load(); got > maxRequests {
+		t.Errorf("server saw %d requests at once, want at most %d", got, maxRequests)
+	}
+}
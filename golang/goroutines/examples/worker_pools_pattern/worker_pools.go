@@ -1,7 +1,9 @@
 package main
 
 import (
+	"context"
 	"fmt"
+	"sync"
 	"time"
 )
 
@@ -16,6 +18,114 @@ func worker(id int, jobs <-chan int, results chan<- int) {
 	}
 }
 
+// JobResult is a result tagged with the ID of the job that produced it
+type JobResult struct {
+	ID    int
+	Value int
+}
+
+// OrderedCollector turns results that finish in any order back into
+// submission order. Results that arrive early are buffered by job ID until
+// every earlier ID has been emitted.
+//
+// The buffer is not capped: it holds every result that finished ahead of the
+// oldest outstanding job, so one slow job can make it grow with the number of
+// jobs submitted after it. Bound it upstream by limiting how many jobs are in
+// flight at once.
+type OrderedCollector struct {
+	out     chan int
+	pending map[int]int
+	next    int // ID of the next result to emit
+}
+
+// NewOrderedCollector starts reading results, emitting values in ID order
+// starting from firstID. The output is closed when results is closed; any
+// results still waiting on a missing earlier ID are dropped. It is also closed
+// when ctx is done, so a consumer that stops reading Out should cancel ctx to
+// release the collector; producers sending on results should watch ctx too.
+func NewOrderedCollector(ctx context.Context, results <-chan JobResult, firstID int) *OrderedCollector {
+	c := &OrderedCollector{
+		out:     make(chan int),
+		pending: make(map[int]int),
+		next:    firstID,
+	}
+	go c.run(ctx, results)
+	return c
+}
+
+func (c *OrderedCollector) run(ctx context.Context, results <-chan JobResult) {
+	defer close(c.out)
+	for {
+		var result JobResult
+		select {
+		case r, ok := <-results:
+			if !ok {
+				return
+			}
+			result = r
+		case <-ctx.Done():
+			return
+		}
+		c.pending[result.ID] = result.Value
+		// Flush every result that is now in sequence
+		for {
+			value, ok := c.pending[c.next]
+			if !ok {
+				break
+			}
+			select {
+			case c.out <- value:
+			case <-ctx.Done():
+				return
+			}
+			delete(c.pending, c.next)
+			c.next++
+		}
+	}
+}
+
+// Out returns the channel of results in submission order
+func (c *OrderedCollector) Out() <-chan int {
+	return c.out
+}
+
+// orderedWorker is like worker but tags each result with its job ID. Later
+// jobs take less time, so results finish out of order.
+func orderedWorker(id int, jobs <-chan int, results chan<- JobResult, numJobs int) {
+	for job := range jobs {
+		time.Sleep(time.Duration(numJobs-job) * 30 * time.Millisecond)
+		fmt.Printf("Worker %d completed job %d\n", id, job)
+		results <- JobResult{ID: job, Value: job * 2}
+	}
+}
+
+func runOrdered(numJobs, numWorkers int) {
+	jobs := make(chan int)
+	results := make(chan JobResult)
+
+	var wg sync.WaitGroup
+	for w := 1; w <= numWorkers; w++ {
+		wg.Add(1)
+		go func(id int) {
+			defer wg.Done()
+			orderedWorker(id, jobs, results, numJobs)
+		}(w)
+	}
+	go func() {
+		for j := 1; j <= numJobs; j++ {
+			jobs <- j
+		}
+		close(jobs)
+		wg.Wait()
+		close(results)
+	}()
+
+	collector := NewOrderedCollector(context.Background(), results, 1)
+	for result := range collector.Out() {
+		fmt.Printf("Ordered result: %d\n", result)
+	}
+}
+
 func main() {
 	numJobs := 5
 	numWorkers := 3
@@ -29,12 +139,15 @@ func main() {
 		go worker(w, jobs, results)
 	}
 
-	// Send jobs
+	// Send jobs from their own goroutine so workers can hand back results
+	// while later jobs are still waiting to be sent
 	fmt.Println("Sending jobs...")
-	for j := 1; j <= numJobs; j++ {
-		jobs <- j
-	}
-	close(jobs)
+	go func() {
+		for j := 1; j <= numJobs; j++ {
+			jobs <- j
+		}
+		close(jobs)
+	}()
 
 	// Collect results
 	fmt.Println("Collecting results...")
@@ -44,4 +157,8 @@ func main() {
 	}
 
 	fmt.Println("All jobs completed")
+
+	// Same work, but results are emitted in job order
+	fmt.Println("Collecting results in submission order...")
+	runOrdered(numJobs, numWorkers)
 }
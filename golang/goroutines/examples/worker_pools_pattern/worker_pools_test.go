@@ -0,0 +1,91 @@
+package main
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// collect drains out, failing the test if it isn't closed in time
+func collect(t *testing.T, out <-chan int) []int {
+	t.Helper()
+	var got []int
+	timeout := time.After(time.Second)
+	for {
+		select {
+		case v, ok := <-out:
+			if !ok {
+				return got
+			}
+			got = append(got, v)
+		case <-timeout:
+			t.Fatalf("output not closed, got %v so far", got)
+		}
+	}
+}
+
+func TestOrderedCollectorEmitsInSubmissionOrder(t *testing.T) {
+	tests := []struct {
+		name    string
+		arrival []int
+		want    []int
+	}{
+		{name: "in order", arrival: []int{1, 2, 3}, want: []int{10, 20, 30}},
+		{name: "reversed", arrival: []int{5, 4, 3, 2, 1}, want: []int{10, 20, 30, 40, 50}},
+		{name: "shuffled", arrival: []int{3, 1, 2, 5, 4}, want: []int{10, 20, 30, 40, 50}},
+		// 3 never arrives, so 4 is dropped when results closes
+		{name: "gap", arrival: []int{2, 4, 1}, want: []int{10, 20}},
+		{name: "empty", arrival: nil, want: nil},
+	}
+	for _, tt := range tests {
+		results := make(chan JobResult, len(tt.arrival))
+		for _, id := range tt.arrival {
+			results <- JobResult{ID: id, Value: id * 10}
+		}
+		close(results)
+
+		collector := NewOrderedCollector(context.Background(), results, 1)
+		if got := collect(t, collector.Out()); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: Out() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestOrderedCollectorEmitsAsSoonAsNextIDArrives(t *testing.T) {
+	results := make(chan JobResult)
+	collector := NewOrderedCollector(context.Background(), results, 1)
+	defer close(results)
+
+	results <- JobResult{ID: 2, Value: 20}
+	select {
+	case v := <-collector.Out():
+		t.Fatalf("emitted %d before job 1 finished", v)
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	results <- JobResult{ID: 1, Value: 10}
+	for _, want := range []int{10, 20} {
+		if got := <-collector.Out(); got != want {
+			t.Errorf("Out() = %d, want %d", got, want)
+		}
+	}
+}
+
+func TestOrderedCollectorStopsOnCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	results := make(chan JobResult, 2)
+	results <- JobResult{ID: 1, Value: 10}
+	results <- JobResult{ID: 2, Value: 20}
+	collector := NewOrderedCollector(ctx, results, 1)
+
+	// The consumer reads one value and walks away; cancelling must release
+	// the collector even though results is never closed
+	if got := <-collector.Out(); got != 10 {
+		t.Fatalf("Out() = %d, want 10", got)
+	}
+	cancel()
+	if got := collect(t, collector.Out()); len(got) > 1 {
+		t.Errorf("Out() after cancel = %v, want at most the value already being sent", got)
+	}
+}
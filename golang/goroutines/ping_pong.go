@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"net/http"
@@ -8,11 +9,66 @@ import (
 	"os"
 	"runtime"
 	"runtime/trace"
+	"sync"
 	"time"
 )
 
 type Ball struct{ hits int }
 
+// Game runs a ping/pong exchange between two players until it is stopped
+type Game struct {
+	Delay time.Duration // pause between hits; defaults to one second
+
+	mu     sync.Mutex
+	cancel context.CancelFunc
+}
+
+// Start plays until ctx is cancelled or Stop is called and returns the final
+// hit count. It blocks for the whole game.
+func (g *Game) Start(ctx context.Context) int {
+	ctx, cancel := context.WithCancel(ctx)
+	g.mu.Lock()
+	g.cancel = cancel
+	g.mu.Unlock()
+	defer cancel()
+
+	table := make(chan *Ball)
+	var wg sync.WaitGroup
+	for _, name := range []string{"ping", "pong"} {
+		wg.Add(1)
+		go func(name string) {
+			defer wg.Done()
+			g.player(ctx, name, table)
+		}(name)
+	}
+
+	ball := new(Ball)
+	select {
+	case table <- ball: // game on; toss the ball
+	case <-ctx.Done():
+	}
+
+	<-ctx.Done()
+	wg.Wait() // game over; both players have left the table
+	return ball.hits
+}
+
+// Stop ends a running game
+func (g *Game) Stop() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.cancel != nil {
+		g.cancel()
+	}
+}
+
+func (g *Game) delay() time.Duration {
+	if g.Delay <= 0 {
+		return time.Second
+	}
+	return g.Delay
+}
+
 func main() {
 
 	go func() {
@@ -34,33 +90,38 @@ func main() {
 		log.Fatalf("failed to start trace: %v", err)
 	}
 	defer trace.Stop()
-	table := make(chan *Ball)
-	go player("ping", table)
-	go player("pong", table)
 
-	table <- new(Ball) // game on; toss the ball
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
 
-	time.Sleep(50 * time.Second)
-	<-table // game over; grab the ball
+	game := &Game{Delay: 500 * time.Millisecond}
+	hits := game.Start(ctx)
+	fmt.Println("Game over after", hits, "hits")
 }
 
-func player(name string, table chan *Ball) {
-	defer func() {
-		if r := recover(); r != nil {
-			fmt.Println("Recovered from panic:", r)
-		}
-	}()
+func (g *Game) player(ctx context.Context, name string, table chan *Ball) {
 	for {
 		fmt.Println("Number of Goroutines:", runtime.NumGoroutine())
-		ball := <-table
+		var ball *Ball
+		select {
+		case ball = <-table:
+		case <-ctx.Done():
+			return
+		}
+
 		ball.hits++
 		fmt.Println(name, ball.hits)
-		a := []string{}
-		for i := 0; i < 100; i++ {
-			a = append(a, "ahdkkkkkkkkkkkkkkkkkkkkkkkkkkkkkkkkkkkkkkkkkkkkkkkkkkkkkkkkkkkkkkkkkkkkkkkkkkkkkkkkkkkkkkkkkkkkkkkkkkkkkkkkkkkkkkkkkkkkkkkkkkkkkkkkkkkkkkkkkkkkkkkkkkkkks")
+
+		select {
+		case <-time.After(g.delay()):
+		case <-ctx.Done():
+			return
+		}
+
+		select {
+		case table <- ball:
+		case <-ctx.Done():
+			return
 		}
-		time.Sleep(1000 * time.Millisecond)
-		//panic("show me the stacks")
-		table <- ball
 	}
 }
@@ -0,0 +1,45 @@
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestGameHitCount(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 105*time.Millisecond)
+	defer cancel()
+
+	// One hit right away, then one per 10ms delay: about 11 in 105ms.
+	// The bounds leave room for a slow scheduler.
+	hits := (&Game{Delay: 10 * time.Millisecond}).Start(ctx)
+	if hits < 3 || hits > 11 {
+		t.Errorf("hits = %d, want between 3 and 11", hits)
+	}
+}
+
+func TestGameStop(t *testing.T) {
+	game := &Game{Delay: time.Millisecond}
+	time.AfterFunc(30*time.Millisecond, game.Stop)
+
+	result := make(chan int)
+	go func() { result <- game.Start(context.Background()) }()
+	select {
+	case hits := <-result:
+		if hits == 0 {
+			t.Error("hits = 0, want the game to have been played")
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("Start did not return after Stop")
+	}
+}
+
+func TestGameCancelledBeforeStart(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if hits := (&Game{}).Start(ctx); hits != 0 {
+		t.Errorf("hits = %d, want 0", hits)
+	}
+	// Stop on a game that never ran is a no-op
+	(&Game{}).Stop()
+}
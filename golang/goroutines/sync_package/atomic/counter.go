@@ -0,0 +1,64 @@
+package main
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+)
+
+// Counter is a counter that can be incremented from many goroutines
+type Counter interface {
+	Inc()
+	Value() int64
+}
+
+// AtomicCounter increments with a single atomic add; no goroutine ever blocks
+type AtomicCounter struct {
+	n atomic.Int64
+}
+
+func (c *AtomicCounter) Inc()         { c.n.Add(1) }
+func (c *AtomicCounter) Value() int64 { return c.n.Load() }
+
+// MutexCounter guards a plain int64 with a mutex; contending goroutines wait
+// their turn for the lock
+type MutexCounter struct {
+	mu sync.Mutex
+	n  int64
+}
+
+func (c *MutexCounter) Inc() {
+	c.mu.Lock()
+	c.n++
+	c.mu.Unlock()
+}
+
+func (c *MutexCounter) Value() int64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.n
+}
+
+// incrementConcurrently runs goroutines that each call Inc perGoroutine times
+func incrementConcurrently(c Counter, goroutines, perGoroutine int) {
+	var wg sync.WaitGroup
+	for g := 0; g < goroutines; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < perGoroutine; i++ {
+				c.Inc()
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+// `go test -race` checks both counters; `go test -bench .` compares them
+func main() {
+	const goroutines, perGoroutine = 50, 1000
+	for _, c := range []Counter{&AtomicCounter{}, &MutexCounter{}} {
+		incrementConcurrently(c, goroutines, perGoroutine)
+		fmt.Printf("%T: %d (want %d)\n", c, c.Value(), goroutines*perGoroutine)
+	}
+}
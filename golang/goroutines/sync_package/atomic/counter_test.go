@@ -0,0 +1,26 @@
+package main
+
+import "testing"
+
+// Run with -race to confirm neither counter races
+func TestCountersUnderContention(t *testing.T) {
+	const goroutines, perGoroutine = 50, 1000
+	for _, c := range []Counter{&AtomicCounter{}, &MutexCounter{}} {
+		incrementConcurrently(c, goroutines, perGoroutine)
+		if got, want := c.Value(), int64(goroutines*perGoroutine); got != want {
+			t.Errorf("%T.Value() = %d, want %d", c, got, want)
+		}
+	}
+}
+
+// benchmarkCounter increments from GOMAXPROCS goroutines at once
+func benchmarkCounter(b *testing.B, c Counter) {
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			c.Inc()
+		}
+	})
+}
+
+func BenchmarkAtomicCounter(b *testing.B) { benchmarkCounter(b, &AtomicCounter{}) }
+func BenchmarkMutexCounter(b *testing.B)  { benchmarkCounter(b, &MutexCounter{}) }
@@ -0,0 +1,159 @@
+package main
+
+import (
+	"container/list"
+	"fmt"
+	"sync"
+)
+
+// An LFU (least frequently used) cache groups entries into buckets by how
+// many times they have been used. Each bucket is a doubly linked list ordered
+// from most to least recently used, so the entry to evict is the back of the
+// lowest-frequency bucket. Tracking that lowest frequency keeps Get and Put
+// O(1).
+
+type lfuEntry[K comparable, V any] struct {
+	key   K
+	value V
+	freq  int
+}
+
+// LFUCache is a fixed-capacity cache that evicts the least frequently used
+// entry when full, breaking ties by evicting the least recently used one.
+// It is safe for concurrent use.
+type LFUCache[K comparable, V any] struct {
+	mu       sync.Mutex
+	capacity int
+	items    map[K]*list.Element
+	buckets  map[int]*list.List // freq -> entries, front = most recently used
+	minFreq  int
+}
+
+// NewLFUCache creates a cache holding at most capacity entries
+func NewLFUCache[K comparable, V any](capacity int) *LFUCache[K, V] {
+	return &LFUCache[K, V]{
+		capacity: capacity,
+		items:    make(map[K]*list.Element),
+		buckets:  make(map[int]*list.List),
+	}
+}
+
+// touch moves elem to the bucket for one more use and returns its new element
+func (c *LFUCache[K, V]) touch(elem *list.Element) *list.Element {
+	entry := elem.Value.(*lfuEntry[K, V])
+	bucket := c.buckets[entry.freq]
+	bucket.Remove(elem)
+	if bucket.Len() == 0 {
+		delete(c.buckets, entry.freq)
+		if c.minFreq == entry.freq {
+			c.minFreq++
+		}
+	}
+	entry.freq++
+	return c.push(entry)
+}
+
+// push adds entry to the front of its frequency bucket
+func (c *LFUCache[K, V]) push(entry *lfuEntry[K, V]) *list.Element {
+	bucket, ok := c.buckets[entry.freq]
+	if !ok {
+		bucket = list.New()
+		c.buckets[entry.freq] = bucket
+	}
+	elem := bucket.PushFront(entry)
+	c.items[entry.key] = elem
+	return elem
+}
+
+// Get returns the value for key and counts it as a use
+func (c *LFUCache[K, V]) Get(key K) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	elem, ok := c.items[key]
+	if !ok {
+		var zero V
+		return zero, false
+	}
+	return c.touch(elem).Value.(*lfuEntry[K, V]).value, true
+}
+
+// Put stores value under key, counting it as a use. If the cache is full the
+// least frequently used entry is evicted first; among entries used equally
+// often, the least recently used one goes.
+func (c *LFUCache[K, V]) Put(key K, value V) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.capacity <= 0 {
+		return
+	}
+	if elem, ok := c.items[key]; ok {
+		elem.Value.(*lfuEntry[K, V]).value = value
+		c.touch(elem)
+		return
+	}
+	if len(c.items) >= c.capacity {
+		bucket := c.buckets[c.minFreq]
+		victim := bucket.Back()
+		bucket.Remove(victim)
+		if bucket.Len() == 0 {
+			delete(c.buckets, c.minFreq)
+		}
+		delete(c.items, victim.Value.(*lfuEntry[K, V]).key)
+	}
+	c.push(&lfuEntry[K, V]{key: key, value: value, freq: 1})
+	c.minFreq = 1
+}
+
+// Len returns the number of cached entries
+func (c *LFUCache[K, V]) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.items)
+}
+
+// Frequency returns how many times key has been used, or 0 if it isn't cached
+func (c *LFUCache[K, V]) Frequency(key K) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if elem, ok := c.items[key]; ok {
+		return elem.Value.(*lfuEntry[K, V]).freq
+	}
+	return 0
+}
+
+func main() {
+	cache := NewLFUCache[string, int](2)
+	cache.Put("a", 1)
+	cache.Put("b", 2)
+	cache.Get("a")    // a used twice, b once
+	cache.Put("c", 3) // evicts b, the least frequently used
+	_, ok := cache.Get("b")
+	fmt.Println("b still cached:", ok) // false
+
+	// Frequency tie: c and d have both been used once; c was used less
+	// recently, so it is evicted
+	cache = NewLFUCache[string, int](3)
+	cache.Put("a", 1)
+	cache.Get("a")
+	cache.Put("c", 3)
+	cache.Put("d", 4)
+	cache.Put("e", 5) // a has freq 2; c and d have freq 1, c is older
+	_, cCached := cache.Get("c")
+	_, dCached := cache.Get("d")
+	fmt.Println("c cached:", cCached, "d cached:", dCached) // false true
+	fmt.Println("freq a:", cache.Frequency("a"), "freq d:", cache.Frequency("d"), "freq e:", cache.Frequency("e"))
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func(n int) {
+			defer wg.Done()
+			cache.Put(fmt.Sprint(n), n)
+			cache.Get("a")
+		}(i)
+	}
+	wg.Wait()
+	fmt.Println("Len after concurrent use:", cache.Len()) // 3
+}
@@ -0,0 +1,95 @@
+package main
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+)
+
+// cached reports whether key is present without counting it as a use
+func cached[K comparable, V any](c *LFUCache[K, V], key K) bool {
+	return c.Frequency(key) > 0
+}
+
+func TestLFUEvictsLeastFrequent(t *testing.T) {
+	cache := NewLFUCache[string, int](2)
+	cache.Put("a", 1)
+	cache.Put("b", 2)
+	cache.Get("a")    // a used twice, b once
+	cache.Put("c", 3) // evicts b
+
+	if cached(cache, "b") {
+		t.Error("b is still cached, want it evicted as the least frequently used")
+	}
+	if v, ok := cache.Get("a"); !ok || v != 1 {
+		t.Errorf("Get(a) = %d, %v, want 1, true", v, ok)
+	}
+	if v, ok := cache.Get("c"); !ok || v != 3 {
+		t.Errorf("Get(c) = %d, %v, want 3, true", v, ok)
+	}
+}
+
+func TestLFUTieEvictsLeastRecent(t *testing.T) {
+	cache := NewLFUCache[string, int](3)
+	cache.Put("a", 1)
+	cache.Get("a")
+	cache.Put("c", 3)
+	cache.Put("d", 4)
+	cache.Put("e", 5) // a has freq 2; c and d have freq 1 and c is older
+
+	if cached(cache, "c") {
+		t.Error("c is still cached, want it evicted as the older of the freq-1 entries")
+	}
+	for _, key := range []string{"a", "d", "e"} {
+		if !cached(cache, key) {
+			t.Errorf("%s was evicted, want it kept", key)
+		}
+	}
+	tests := map[string]int{"a": 2, "d": 1, "e": 1, "c": 0}
+	for key, want := range tests {
+		if got := cache.Frequency(key); got != want {
+			t.Errorf("Frequency(%s) = %d, want %d", key, got, want)
+		}
+	}
+}
+
+func TestLFUPutExistingCountsAsUse(t *testing.T) {
+	cache := NewLFUCache[string, int](2)
+	cache.Put("a", 1)
+	cache.Put("b", 2)
+	cache.Put("a", 10) // update: a now has freq 2
+	cache.Put("c", 3)  // evicts b
+
+	if v, ok := cache.Get("a"); !ok || v != 10 {
+		t.Errorf("Get(a) = %d, %v, want 10, true", v, ok)
+	}
+	if cached(cache, "b") {
+		t.Error("b is still cached, want it evicted")
+	}
+}
+
+func TestLFUZeroCapacity(t *testing.T) {
+	cache := NewLFUCache[string, int](0)
+	cache.Put("a", 1)
+	if cache.Len() != 0 {
+		t.Errorf("Len() = %d, want 0", cache.Len())
+	}
+}
+
+// Run with -race
+func TestLFUConcurrentUse(t *testing.T) {
+	cache := NewLFUCache[string, int](3)
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func(n int) {
+			defer wg.Done()
+			cache.Put(fmt.Sprint(n), n)
+			cache.Get(fmt.Sprint(n - 1))
+		}(i)
+	}
+	wg.Wait()
+	if got := cache.Len(); got != 3 {
+		t.Errorf("Len() after concurrent use = %d, want 3", got)
+	}
+}
@@ -0,0 +1,119 @@
+package main
+
+import (
+	"container/list"
+	"fmt"
+	"sync"
+)
+
+// An LRU (least recently used) cache pairs a map with a doubly linked list:
+// the map finds an entry in O(1) and the list keeps entries ordered from most
+// to least recently used, so moving or evicting an entry is also O(1).
+
+type lruEntry[K comparable, V any] struct {
+	key   K
+	value V
+}
+
+// LRUCache is a fixed-capacity cache that evicts the least recently used
+// entry when full. It is safe for concurrent use.
+type LRUCache[K comparable, V any] struct {
+	mu       sync.Mutex
+	capacity int
+	items    map[K]*list.Element
+	order    *list.List // front = most recently used
+}
+
+// NewLRUCache creates a cache holding at most capacity entries
+func NewLRUCache[K comparable, V any](capacity int) *LRUCache[K, V] {
+	return &LRUCache[K, V]{
+		capacity: capacity,
+		items:    make(map[K]*list.Element),
+		order:    list.New(),
+	}
+}
+
+// Get returns the value for key and marks it as most recently used.
+// Get takes the full lock because it reorders the list.
+func (c *LRUCache[K, V]) Get(key K) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	elem, ok := c.items[key]
+	if !ok {
+		var zero V
+		return zero, false
+	}
+	c.order.MoveToFront(elem)
+	return elem.Value.(*lruEntry[K, V]).value, true
+}
+
+// Put stores value under key, marking it as most recently used. If the
+// cache is full the least recently used entry is evicted first.
+func (c *LRUCache[K, V]) Put(key K, value V) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.capacity <= 0 {
+		return
+	}
+	if elem, ok := c.items[key]; ok {
+		elem.Value.(*lruEntry[K, V]).value = value
+		c.order.MoveToFront(elem)
+		return
+	}
+	if c.order.Len() >= c.capacity {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.items, oldest.Value.(*lruEntry[K, V]).key)
+	}
+	c.items[key] = c.order.PushFront(&lruEntry[K, V]{key: key, value: value})
+}
+
+// Len returns the number of cached entries
+func (c *LRUCache[K, V]) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.order.Len()
+}
+
+// Keys returns the cached keys from most to least recently used
+func (c *LRUCache[K, V]) Keys() []K {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	keys := make([]K, 0, c.order.Len())
+	for elem := c.order.Front(); elem != nil; elem = elem.Next() {
+		keys = append(keys, elem.Value.(*lruEntry[K, V]).key)
+	}
+	return keys
+}
+
+func main() {
+	cache := NewLRUCache[string, int](2)
+	cache.Put("a", 1)
+	cache.Put("b", 2)
+	fmt.Println("Keys:", cache.Keys()) // [b a]
+
+	// Reading "a" makes it the most recently used entry
+	cache.Get("a")
+	cache.Put("c", 3) // evicts "b"
+	_, ok := cache.Get("b")
+	fmt.Println("b still cached:", ok) // false
+	fmt.Println("Keys:", cache.Keys()) // [c a]
+
+	// Updating an existing key also moves it to the front
+	cache.Put("a", 10)
+	fmt.Println("Keys:", cache.Keys()) // [a c]
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func(n int) {
+			defer wg.Done()
+			cache.Put(fmt.Sprint(n), n)
+			cache.Get("a")
+		}(i)
+	}
+	wg.Wait()
+	fmt.Println("Len after concurrent use:", cache.Len()) // 2
+}
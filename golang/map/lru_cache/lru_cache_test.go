@@ -0,0 +1,75 @@
+package main
+
+import (
+	"reflect"
+	"strconv"
+	"sync"
+	"testing"
+)
+
+func TestLRUCacheEvictionOrder(t *testing.T) {
+	cache := NewLRUCache[string, int](3)
+	cache.Put("a", 1)
+	cache.Put("b", 2)
+	cache.Put("c", 3)
+	cache.Get("a") // order is now a, c, b
+
+	cache.Put("d", 4) // evicts b
+	if _, ok := cache.Get("b"); ok {
+		t.Error("b was not evicted")
+	}
+	cache.Put("e", 5) // evicts c
+	if _, ok := cache.Get("c"); ok {
+		t.Error("c was not evicted")
+	}
+	if got, want := cache.Keys(), []string{"e", "d", "a"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Keys() = %v, want %v", got, want)
+	}
+	if cache.Len() != 3 {
+		t.Errorf("Len() = %d, want 3", cache.Len())
+	}
+}
+
+func TestLRUCacheUpdateMovesToFront(t *testing.T) {
+	cache := NewLRUCache[string, int](2)
+	cache.Put("a", 1)
+	cache.Put("b", 2)
+	cache.Put("a", 10) // a becomes most recently used
+
+	cache.Put("c", 3) // so b is evicted instead of a
+	if v, ok := cache.Get("a"); !ok || v != 10 {
+		t.Errorf("Get(a) = %d, %t, want 10, true", v, ok)
+	}
+	if _, ok := cache.Get("b"); ok {
+		t.Error("b was not evicted after a was updated")
+	}
+	if cache.Len() != 2 {
+		t.Errorf("Len() = %d, want 2", cache.Len())
+	}
+}
+
+func TestLRUCacheZeroCapacity(t *testing.T) {
+	cache := NewLRUCache[string, int](0)
+	cache.Put("a", 1)
+	if _, ok := cache.Get("a"); ok || cache.Len() != 0 {
+		t.Errorf("zero-capacity cache stored a value: Len() = %d", cache.Len())
+	}
+}
+
+func TestLRUCacheConcurrentUse(t *testing.T) {
+	cache := NewLRUCache[string, int](8)
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func(n int) {
+			defer wg.Done()
+			cache.Put(strconv.Itoa(n), n)
+			cache.Get(strconv.Itoa(n - 1))
+			cache.Keys()
+		}(i)
+	}
+	wg.Wait()
+	if cache.Len() != 8 {
+		t.Errorf("Len() = %d, want 8", cache.Len())
+	}
+}
@@ -1,11 +1,46 @@
 
 package main
 
-import "fmt"
+import (
+	"fmt"
+	"reflect"
+)
 
 // Example of using interfaces as map keys in Go.
 // This demonstrates how interfaces can be used as map keys when they contain comparable types.
 
+// DescribeKey returns a human-readable "type=..., value=..." description of a
+// map key, naming the common key types explicitly
+func DescribeKey(k any) string {
+	switch v := k.(type) {
+	case nil:
+		return "type=nil, value=nil"
+	case int:
+		return fmt.Sprintf("type=int, value=%d", v)
+	case int64:
+		return fmt.Sprintf("type=int64, value=%d", v)
+	case float64:
+		return fmt.Sprintf("type=float64, value=%g", v)
+	case string:
+		return fmt.Sprintf("type=string, value=%q", v)
+	case bool:
+		return fmt.Sprintf("type=bool, value=%t", v)
+	default:
+		return fmt.Sprintf("type=%T, value=%v", v, v)
+	}
+}
+
+// IsComparable reports whether v can be used as a map key without
+// panicking. Slices, maps and funcs are not comparable, and neither are
+// arrays or structs that contain them, even when stored in an interface.
+func IsComparable(v any) bool {
+	rv := reflect.ValueOf(v)
+	if !rv.IsValid() {
+		return true // a nil interface is a valid key
+	}
+	return rv.Comparable()
+}
+
 func main() {
 	// Create a map with interface{} as key type
 	m := make(map[interface{}]string)
@@ -40,4 +75,11 @@ func main() {
 
 	// Similarly, maps are not comparable:
 	// m[map[string]int{"a": 1}] = "Map key" // panic!
+
+	// Check before inserting instead of panicking
+	candidates := []interface{}{42, "hello", []int{1, 2, 3}, map[string]int{"a": 1}, struct{ s []int }{}}
+	fmt.Println()
+	for _, k := range candidates {
+		fmt.Printf("%s -> comparable: %v\n", DescribeKey(k), IsComparable(k))
+	}
 }
@@ -0,0 +1,59 @@
+package main
+
+import "testing"
+
+func TestIsComparable(t *testing.T) {
+	tests := []struct {
+		name string
+		v    any
+		want bool
+	}{
+		{"int", 42, true},
+		{"string", "hello", true},
+		{"nil", nil, true},
+		{"pointer", new(int), true},
+		{"struct of comparables", struct{ x int }{1}, true},
+		{"array of ints", [2]int{1, 2}, true},
+		{"slice", []int{}, false},
+		{"nil slice", []int(nil), false},
+		{"map", map[string]int{"a": 1}, false},
+		{"func", func() {}, false},
+		{"struct with slice", struct{ s []int }{}, false},
+		{"array of slices", [1][]int{}, false},
+		{"struct holding a slice in an interface", struct{ v any }{[]int{1}}, false},
+		{"struct holding an int in an interface", struct{ v any }{1}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := IsComparable(tt.v)
+			if got != tt.want {
+				t.Fatalf("IsComparable(%#v) = %t, want %t", tt.v, got, tt.want)
+			}
+			if got {
+				// Comparable values must really work as keys
+				m := map[any]bool{}
+				m[tt.v] = true
+			}
+		})
+	}
+}
+
+func TestDescribeKey(t *testing.T) {
+	tests := []struct {
+		k    any
+		want string
+	}{
+		{nil, "type=nil, value=nil"},
+		{42, "type=int, value=42"},
+		{int64(42), "type=int64, value=42"},
+		{3.5, "type=float64, value=3.5"},
+		{"go", `type=string, value="go"`},
+		{true, "type=bool, value=true"},
+		{struct{ x int }{1}, "type=struct { x int }, value={1}"},
+	}
+	for _, tt := range tests {
+		if got := DescribeKey(tt.k); got != tt.want {
+			t.Errorf("DescribeKey(%#v) = %q, want %q", tt.k, got, tt.want)
+		}
+	}
+}
@@ -2,6 +2,24 @@ package main
 
 import "fmt"
 
+// DedupeByValue returns one pointer per distinct pointed-to value, keeping the
+// first pointer seen for each value and preserving input order. A map keyed
+// by the pointers themselves would keep all of them, because pointer keys
+// compare by address; keying by the dereferenced value compares by content.
+// Nil pointers have no value to compare and are dropped.
+func DedupeByValue[T comparable](items []*T) []*T {
+	seen := make(map[T]bool, len(items))
+	result := make([]*T, 0, len(items))
+	for _, item := range items {
+		if item == nil || seen[*item] {
+			continue
+		}
+		seen[*item] = true
+		result = append(result, item)
+	}
+	return result
+}
+
 func main() {
 	type Person struct {
 		Name string
@@ -40,4 +58,11 @@ func main() {
 
 	// Check if p1 and p3 are treated as different keys
 	fmt.Printf("p1 == p3: %v\n", p1 == p3) // Output: false
+
+	// Collapse pointers to equal values when identity isn't what you want
+	alice1 := &Person{Name: "Alice"}
+	alice2 := &Person{Name: "Alice"}
+	bob := &Person{Name: "Bob"}
+	unique := DedupeByValue([]*Person{alice1, bob, alice2})
+	fmt.Printf("Unique people: %d, first Alice kept: %v\n", len(unique), unique[0] == alice1) // 2, true
 }
@@ -0,0 +1,45 @@
+package main
+
+import "testing"
+
+type dedupePerson struct {
+	Name string
+	Age  int
+}
+
+func TestDedupeByValueCollapsesEqualValues(t *testing.T) {
+	alice1 := &dedupePerson{"Alice", 30}
+	alice2 := &dedupePerson{"Alice", 30}
+	if alice1 == alice2 {
+		t.Fatal("test needs two distinct pointers")
+	}
+
+	got := DedupeByValue([]*dedupePerson{alice1, alice2})
+	if len(got) != 1 || got[0] != alice1 {
+		t.Errorf("DedupeByValue = %v, want only the first pointer %p", got, alice1)
+	}
+}
+
+func TestDedupeByValueKeepsOrderAndDistinctValues(t *testing.T) {
+	alice := &dedupePerson{"Alice", 30}
+	bob := &dedupePerson{"Bob", 25}
+	olderAlice := &dedupePerson{"Alice", 31}
+	items := []*dedupePerson{alice, bob, nil, &dedupePerson{"Bob", 25}, olderAlice, alice}
+
+	got := DedupeByValue(items)
+	want := []*dedupePerson{alice, bob, olderAlice}
+	if len(got) != len(want) {
+		t.Fatalf("DedupeByValue returned %d pointers, want %d", len(got), len(want))
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("result[%d] = %+v (%p), want %+v (%p)", i, *got[i], got[i], *want[i], want[i])
+		}
+	}
+}
+
+func TestDedupeByValueEmpty(t *testing.T) {
+	if got := DedupeByValue[int](nil); len(got) != 0 {
+		t.Errorf("DedupeByValue(nil) = %v, want empty", got)
+	}
+}
@@ -3,9 +3,11 @@ package main
 import (
 	"crypto/sha256"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"reflect"
 	"runtime"
+	"sort"
 	"strings"
 )
 
@@ -33,6 +35,28 @@ func (p PersonWithUnsupportedFields) Hash() string {
 	return fmt.Sprintf("%x", h.Sum(nil))
 }
 
+// HashKey returns a stable SHA-256 hex digest of v that can be used as a map
+// key for values that are not comparable themselves (slices, maps, or structs
+// containing them). v is encoded as JSON first, which writes map keys in
+// sorted order, so two structurally equal values always hash the same.
+//
+// Functions and channels have no stable encoding (the runtime.FuncForPC
+// trick above breaks for closures and across builds), so a value containing
+// one is rejected with an error. Unexported struct fields are not encoded
+// and therefore do not affect the hash.
+func HashKey(v any) (string, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		var unsupported *json.UnsupportedTypeError
+		if errors.As(err, &unsupported) {
+			return "", fmt.Errorf("hash key: %s values cannot be hashed", unsupported.Type)
+		}
+		return "", fmt.Errorf("hash key: %w", err)
+	}
+	sum := sha256.Sum256(data)
+	return fmt.Sprintf("%x", sum), nil
+}
+
 // Approach 2: Transform non-comparable fields into comparable types
 // =============================================================
 
@@ -54,10 +78,19 @@ func (p PersonWithUnsupportedFields) Convert() ComparablePerson {
 }
 
 // Helper functions for conversion
+
+// mapToString joins the pairs sorted by key. Map iteration order is random,
+// so without sorting the same map could produce a different key each time.
 func mapToString(m map[string]string) string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
 	pairs := make([]string, 0, len(m))
-	for k, v := range m {
-		pairs = append(pairs, k+"="+v)
+	for _, k := range keys {
+		pairs = append(pairs, k+"="+m[k])
 	}
 	return strings.Join(pairs, ";")
 }
@@ -101,6 +134,24 @@ func main() {
 	retrievedPerson := hashedMap[person1.Hash()]
 	fmt.Printf("Retrieved: %s with hobbies: %v\n\n", retrievedPerson.Name, retrievedPerson.Hobbies)
 
+	fmt.Println("Approach 1b: Using HashKey")
+	fmt.Println("==========================")
+
+	type Profile struct {
+		Name      string
+		Hobbies   []string
+		Addresses map[string]string
+	}
+	profileA := Profile{"John", []string{"reading"}, map[string]string{"home": "1", "work": "2"}}
+	profileB := Profile{"John", []string{"reading"}, map[string]string{"work": "2", "home": "1"}}
+	keyA, _ := HashKey(profileA)
+	keyB, _ := HashKey(profileB)
+	fmt.Printf("Equal profiles hash equal: %v\n", keyA == keyB)
+
+	if _, err := HashKey(person1); err != nil {
+		fmt.Printf("Struct with a func field: %v\n\n", err)
+	}
+
 	fmt.Println("Approach 2: Using Transformation")
 	fmt.Println("==============================")
 	
@@ -115,6 +166,11 @@ func main() {
 	fmt.Printf("Converted hobbies string: %s\n", converted1.HobbiesString)
 	fmt.Printf("Status: %s\n", comparableMap[converted1])
 
+	// The same map built in a different order yields the same string
+	forward := map[string]string{"a": "1", "b": "2", "c": "3"}
+	backward := map[string]string{"c": "3", "b": "2", "a": "1"}
+	fmt.Printf("Deterministic map string: %v\n", mapToString(forward) == mapToString(backward))
+
 	// Demonstrate that both approaches maintain uniqueness
 	fmt.Println("\nUniqueness Demonstration")
 	fmt.Println("=======================")
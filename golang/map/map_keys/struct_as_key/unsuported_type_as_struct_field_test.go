@@ -0,0 +1,102 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+type hashProfile struct {
+	Name      string
+	Hobbies   []string
+	Addresses map[string]string
+}
+
+func TestHashKeyEqualValues(t *testing.T) {
+	// Same content, maps written in a different order
+	a := hashProfile{"John", []string{"reading", "chess"}, map[string]string{"home": "1", "work": "2", "gym": "3"}}
+	b := hashProfile{"John", []string{"reading", "chess"}, map[string]string{"gym": "3", "work": "2", "home": "1"}}
+
+	keyA, err := HashKey(a)
+	if err != nil {
+		t.Fatalf("HashKey(a): %v", err)
+	}
+	keyB, err := HashKey(b)
+	if err != nil {
+		t.Fatalf("HashKey(b): %v", err)
+	}
+	if keyA != keyB {
+		t.Errorf("equal values hashed differently: %s != %s", keyA, keyB)
+	}
+	if len(keyA) != 64 {
+		t.Errorf("len(key) = %d, want 64 hex characters", len(keyA))
+	}
+}
+
+func TestHashKeyDifferentValues(t *testing.T) {
+	base := hashProfile{"John", []string{"reading"}, map[string]string{"home": "1"}}
+	variants := map[string]hashProfile{
+		"name":         {"Jane", []string{"reading"}, map[string]string{"home": "1"}},
+		"extra hobby":  {"John", []string{"reading", "chess"}, map[string]string{"home": "1"}},
+		"map value":    {"John", []string{"reading"}, map[string]string{"home": "2"}},
+		"map key":      {"John", []string{"reading"}, map[string]string{"work": "1"}},
+		"no addresses": {"John", []string{"reading"}, nil},
+		"no hobbies":   {"John", nil, map[string]string{"home": "1"}},
+	}
+
+	baseKey, err := HashKey(base)
+	if err != nil {
+		t.Fatalf("HashKey(base): %v", err)
+	}
+	for name, v := range variants {
+		key, err := HashKey(v)
+		if err != nil {
+			t.Fatalf("HashKey(%s): %v", name, err)
+		}
+		if key == baseKey {
+			t.Errorf("%s: different value hashed the same as base", name)
+		}
+	}
+}
+
+func TestHashKeyRejectsFuncs(t *testing.T) {
+	person := PersonWithUnsupportedFields{
+		Name:     "John",
+		Callback: func(s string) string { return s },
+	}
+	key, err := HashKey(person)
+	if err == nil {
+		t.Fatalf("HashKey of a struct with a func field = %q, want an error", key)
+	}
+	if !strings.Contains(err.Error(), "cannot be hashed") {
+		t.Errorf("error = %q, want it to say the value cannot be hashed", err)
+	}
+
+	if _, err := HashKey(make(chan int)); err == nil {
+		t.Error("HashKey of a channel succeeded, want an error")
+	}
+}
+
+func TestMapToStringIsDeterministic(t *testing.T) {
+	forward := map[string]string{}
+	for _, k := range []string{"a", "b", "c", "d", "e", "f", "g", "h"} {
+		forward[k] = strings.ToUpper(k)
+	}
+	backward := map[string]string{}
+	for _, k := range []string{"h", "g", "f", "e", "d", "c", "b", "a"} {
+		backward[k] = strings.ToUpper(k)
+	}
+
+	want := "a=A;b=B;c=C;d=D;e=E;f=F;g=G;h=H"
+	// Map iteration order changes between ranges, so check repeatedly
+	for i := 0; i < 20; i++ {
+		if got := mapToString(forward); got != want {
+			t.Fatalf("mapToString(forward) = %q, want %q", got, want)
+		}
+		if got := mapToString(backward); got != want {
+			t.Fatalf("mapToString(backward) = %q, want %q", got, want)
+		}
+	}
+	if got := mapToString(nil); got != "" {
+		t.Errorf("mapToString(nil) = %q, want empty", got)
+	}
+}
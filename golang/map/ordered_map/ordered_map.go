@@ -0,0 +1,91 @@
+package main
+
+import "fmt"
+
+// Iterating a Go map visits keys in a deliberately randomized order, so two
+// runs of the same program can print a map differently. OrderedMap keeps a
+// slice of keys next to the map to remember the order keys were inserted.
+
+type orderedEntry[V any] struct {
+	value V
+	index int // position of the key in OrderedMap.keys
+}
+
+// OrderedMap is a map that iterates in insertion order
+type OrderedMap[K comparable, V any] struct {
+	keys    []K
+	entries map[K]orderedEntry[V]
+}
+
+// NewOrderedMap creates an empty OrderedMap
+func NewOrderedMap[K comparable, V any]() *OrderedMap[K, V] {
+	return &OrderedMap[K, V]{entries: make(map[K]orderedEntry[V])}
+}
+
+// Set stores value under key. Updating an existing key keeps its position.
+func (m *OrderedMap[K, V]) Set(key K, value V) {
+	if e, ok := m.entries[key]; ok {
+		e.value = value
+		m.entries[key] = e
+		return
+	}
+	m.entries[key] = orderedEntry[V]{value: value, index: len(m.keys)}
+	m.keys = append(m.keys, key)
+}
+
+// Get returns the value stored under key
+func (m *OrderedMap[K, V]) Get(key K) (V, bool) {
+	e, ok := m.entries[key]
+	return e.value, ok
+}
+
+// Delete removes key and reports whether it was present. Setting the key
+// again afterwards places it at the end of the order.
+func (m *OrderedMap[K, V]) Delete(key K) bool {
+	e, ok := m.entries[key]
+	if !ok {
+		return false
+	}
+	delete(m.entries, key)
+	m.keys = append(m.keys[:e.index], m.keys[e.index+1:]...)
+
+	// Every key after the removed one shifted left by one position
+	for i := e.index; i < len(m.keys); i++ {
+		shifted := m.entries[m.keys[i]]
+		shifted.index = i
+		m.entries[m.keys[i]] = shifted
+	}
+	return true
+}
+
+// Len returns the number of keys in the map
+func (m *OrderedMap[K, V]) Len() int {
+	return len(m.keys)
+}
+
+// Keys returns the keys in insertion order
+func (m *OrderedMap[K, V]) Keys() []K {
+	return append([]K(nil), m.keys...)
+}
+
+func main() {
+	m := NewOrderedMap[string, int]()
+	m.Set("one", 1)
+	m.Set("two", 2)
+	m.Set("three", 3)
+	fmt.Println("Keys:", m.Keys()) // [one two three]
+
+	// Updating keeps the position
+	m.Set("one", 100)
+	fmt.Println("After update:", m.Keys()) // [one two three]
+
+	// Deleting and re-inserting moves the key to the end
+	m.Delete("one")
+	m.Set("one", 1)
+	fmt.Println("After re-insert:", m.Keys()) // [two three one]
+
+	for _, k := range m.Keys() {
+		v, _ := m.Get(k)
+		fmt.Printf("  %s = %d\n", k, v)
+	}
+}
@@ -0,0 +1,76 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestOrderedMapIterationOrder(t *testing.T) {
+	m := NewOrderedMap[string, int]()
+	want := []string{"zeta", "alpha", "mu", "beta", "omega"}
+	for i, k := range want {
+		m.Set(k, i)
+	}
+
+	// Go randomizes map iteration, so repeat to catch any dependence on it
+	for i := 0; i < 20; i++ {
+		if got := m.Keys(); !reflect.DeepEqual(got, want) {
+			t.Fatalf("Keys() = %v, want %v", got, want)
+		}
+	}
+}
+
+func TestOrderedMapUpdateKeepsPosition(t *testing.T) {
+	m := NewOrderedMap[string, int]()
+	m.Set("one", 1)
+	m.Set("two", 2)
+	m.Set("one", 100)
+
+	if got, want := m.Keys(), []string{"one", "two"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Keys() = %v, want %v", got, want)
+	}
+	if v, ok := m.Get("one"); !ok || v != 100 {
+		t.Errorf("Get(one) = %d, %t, want 100, true", v, ok)
+	}
+	if m.Len() != 2 {
+		t.Errorf("Len() = %d, want 2", m.Len())
+	}
+}
+
+func TestOrderedMapDelete(t *testing.T) {
+	m := NewOrderedMap[string, int]()
+	for i, k := range []string{"a", "b", "c", "d"} {
+		m.Set(k, i)
+	}
+	if !m.Delete("b") {
+		t.Fatal("Delete(b) = false, want true")
+	}
+	if m.Delete("b") {
+		t.Error("second Delete(b) = true, want false")
+	}
+	if got, want := m.Keys(), []string{"a", "c", "d"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Keys() after delete = %v, want %v", got, want)
+	}
+
+	// The indexes of the shifted keys must still be right for later deletes
+	if !m.Delete("d") {
+		t.Fatal("Delete(d) = false, want true")
+	}
+	m.Set("b", 10)
+	if got, want := m.Keys(), []string{"a", "c", "b"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Keys() after re-insert = %v, want %v", got, want)
+	}
+	if _, ok := m.Get("d"); ok {
+		t.Error("Get(d) found a deleted key")
+	}
+}
+
+func TestOrderedMapKeysIsACopy(t *testing.T) {
+	m := NewOrderedMap[int, string]()
+	m.Set(1, "one")
+	keys := m.Keys()
+	keys[0] = 42
+	if got := m.Keys()[0]; got != 1 {
+		t.Errorf("Keys()[0] = %d after mutating a returned slice, want 1", got)
+	}
+}
@@ -0,0 +1,130 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// Go has no built-in set type. The idiomatic replacement is a map whose
+// values carry no data: map[T]struct{}. An empty struct takes zero bytes,
+// so only the keys cost memory, and key lookup gives O(1) membership.
+
+// Set is a thread-safe set of comparable values
+type Set[T comparable] struct {
+	mu    sync.RWMutex
+	items map[T]struct{}
+}
+
+// NewSet creates a set containing the given items
+func NewSet[T comparable](items ...T) *Set[T] {
+	s := &Set[T]{items: make(map[T]struct{}, len(items))}
+	for _, item := range items {
+		s.items[item] = struct{}{}
+	}
+	return s
+}
+
+// Add inserts item into the set
+func (s *Set[T]) Add(item T) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.items == nil {
+		s.items = make(map[T]struct{})
+	}
+	s.items[item] = struct{}{}
+}
+
+// Remove deletes item from the set
+func (s *Set[T]) Remove(item T) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.items, item)
+}
+
+// Contains reports whether item is in the set
+func (s *Set[T]) Contains(item T) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	_, ok := s.items[item]
+	return ok
+}
+
+// Len returns the number of items in the set
+func (s *Set[T]) Len() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return len(s.items)
+}
+
+// Items returns the set members in no particular order
+func (s *Set[T]) Items() []T {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	items := make([]T, 0, len(s.items))
+	for item := range s.items {
+		items = append(items, item)
+	}
+	return items
+}
+
+// Union returns a new set with the items of both sets
+func (s *Set[T]) Union(other *Set[T]) *Set[T] {
+	result := NewSet(s.Items()...)
+	for _, item := range other.Items() {
+		result.items[item] = struct{}{}
+	}
+	return result
+}
+
+// Intersect returns a new set with the items present in both sets
+func (s *Set[T]) Intersect(other *Set[T]) *Set[T] {
+	result := NewSet[T]()
+	for _, item := range s.Items() {
+		if other.Contains(item) {
+			result.items[item] = struct{}{}
+		}
+	}
+	return result
+}
+
+// Difference returns a new set with the items of s that are not in other
+func (s *Set[T]) Difference(other *Set[T]) *Set[T] {
+	result := NewSet[T]()
+	for _, item := range s.Items() {
+		if !other.Contains(item) {
+			result.items[item] = struct{}{}
+		}
+	}
+	return result
+}
+
+func sorted(s *Set[int]) []int {
+	items := s.Items()
+	sort.Ints(items)
+	return items
+}
+
+func main() {
+	a := NewSet(1, 2, 3, 4)
+	b := NewSet(3, 4, 5)
+
+	fmt.Println("a:", sorted(a))
+	fmt.Println("b:", sorted(b))
+	fmt.Println("a ∪ b:", sorted(a.Union(b)))      // [1 2 3 4 5]
+	fmt.Println("a ∩ b:", sorted(a.Intersect(b)))  // [3 4]
+	fmt.Println("a - b:", sorted(a.Difference(b))) // [1 2]
+
+	// Concurrent writers are safe because every method takes the lock
+	s := NewSet[int]()
+	var wg sync.WaitGroup
+	for i := 0; i < 100; i++ {
+		wg.Add(1)
+		go func(n int) {
+			defer wg.Done()
+			s.Add(n % 10)
+		}(i)
+	}
+	wg.Wait()
+	fmt.Println("Concurrent adds, distinct values:", s.Len()) // 10
+}
@@ -0,0 +1,97 @@
+package main
+
+import (
+	"reflect"
+	"sync"
+	"testing"
+)
+
+func TestSetAlgebra(t *testing.T) {
+	tests := []struct {
+		name                   string
+		a, b                   []int
+		union, intersect, diff []int
+	}{
+		{
+			name:      "overlapping",
+			a:         []int{1, 2, 3, 4},
+			b:         []int{3, 4, 5},
+			union:     []int{1, 2, 3, 4, 5},
+			intersect: []int{3, 4},
+			diff:      []int{1, 2},
+		},
+		{
+			name:      "disjoint",
+			a:         []int{1, 2},
+			b:         []int{3, 4},
+			union:     []int{1, 2, 3, 4},
+			intersect: []int{},
+			diff:      []int{1, 2},
+		},
+		{
+			name:      "identical",
+			a:         []int{1, 2, 3},
+			b:         []int{1, 2, 3},
+			union:     []int{1, 2, 3},
+			intersect: []int{1, 2, 3},
+			diff:      []int{},
+		},
+		{
+			name:      "empty",
+			a:         []int{},
+			b:         []int{1},
+			union:     []int{1},
+			intersect: []int{},
+			diff:      []int{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, b := NewSet(tt.a...), NewSet(tt.b...)
+			if got := sorted(a.Union(b)); !reflect.DeepEqual(got, tt.union) {
+				t.Errorf("Union = %v, want %v", got, tt.union)
+			}
+			if got := sorted(a.Intersect(b)); !reflect.DeepEqual(got, tt.intersect) {
+				t.Errorf("Intersect = %v, want %v", got, tt.intersect)
+			}
+			if got := sorted(a.Difference(b)); !reflect.DeepEqual(got, tt.diff) {
+				t.Errorf("Difference = %v, want %v", got, tt.diff)
+			}
+			// The operands are left untouched
+			if got := sorted(a); !reflect.DeepEqual(got, tt.a) {
+				t.Errorf("a changed to %v, want %v", got, tt.a)
+			}
+		})
+	}
+}
+
+func TestSetAddRemoveContains(t *testing.T) {
+	var s Set[string] // the zero value is usable
+	s.Add("go")
+	s.Add("go")
+	if !s.Contains("go") || s.Len() != 1 {
+		t.Errorf("after adding twice: Contains = %t, Len = %d, want true, 1", s.Contains("go"), s.Len())
+	}
+	s.Remove("go")
+	s.Remove("missing")
+	if s.Contains("go") || s.Len() != 0 {
+		t.Errorf("after remove: Contains = %t, Len = %d, want false, 0", s.Contains("go"), s.Len())
+	}
+}
+
+func TestSetConcurrentAdd(t *testing.T) {
+	s := NewSet[int]()
+	var wg sync.WaitGroup
+	for i := 0; i < 100; i++ {
+		wg.Add(1)
+		go func(n int) {
+			defer wg.Done()
+			s.Add(n % 10)
+			s.Contains(n)
+		}(i)
+	}
+	wg.Wait()
+	if s.Len() != 10 {
+		t.Errorf("Len = %d, want 10", s.Len())
+	}
+}
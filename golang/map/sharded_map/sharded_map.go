@@ -0,0 +1,100 @@
+package main
+
+import (
+	"fmt"
+	"hash/fnv"
+	"strconv"
+	"sync"
+)
+
+// A single mutex around a map serialises every goroutine that touches it.
+// Sharding splits the keys across several maps, each with its own lock, so
+// goroutines working on keys in different shards do not block each other.
+
+type shard[V any] struct {
+	mu    sync.RWMutex
+	items map[string]V
+}
+
+// ShardedMap is a string-keyed map that is safe for concurrent use
+type ShardedMap[V any] struct {
+	shards []*shard[V]
+}
+
+// NewShardedMap creates a map split across shardCount shards (at least 1)
+func NewShardedMap[V any](shardCount int) *ShardedMap[V] {
+	shardCount = max(shardCount, 1)
+	m := &ShardedMap[V]{shards: make([]*shard[V], shardCount)}
+	for i := range m.shards {
+		m.shards[i] = &shard[V]{items: make(map[string]V)}
+	}
+	return m
+}
+
+// shardFor picks the shard for key using an FNV-1a hash
+func (m *ShardedMap[V]) shardFor(key string) *shard[V] {
+	h := fnv.New32a()
+	h.Write([]byte(key))
+	return m.shards[h.Sum32()%uint32(len(m.shards))]
+}
+
+// Get returns the value stored under key
+func (m *ShardedMap[V]) Get(key string) (V, bool) {
+	s := m.shardFor(key)
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	value, ok := s.items[key]
+	return value, ok
+}
+
+// Set stores value under key
+func (m *ShardedMap[V]) Set(key string, value V) {
+	s := m.shardFor(key)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.items[key] = value
+}
+
+// Delete removes key
+func (m *ShardedMap[V]) Delete(key string) {
+	s := m.shardFor(key)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.items, key)
+}
+
+// Len returns the number of keys. Shards are counted one at a time, so the
+// result may be stale if other goroutines are writing.
+func (m *ShardedMap[V]) Len() int {
+	total := 0
+	for _, s := range m.shards {
+		s.mu.RLock()
+		total += len(s.items)
+		s.mu.RUnlock()
+	}
+	return total
+}
+
+// Run with `go run -race sharded_map.go` to confirm the map does not race
+func main() {
+	m := NewShardedMap[int](8)
+	var wg sync.WaitGroup
+	for i := 0; i < 100; i++ {
+		wg.Add(1)
+		go func(n int) {
+			defer wg.Done()
+			m.Set(strconv.Itoa(n), n*n)
+		}(i)
+	}
+	wg.Wait()
+
+	value, ok := m.Get("12")
+	fmt.Println("Len:", m.Len(), "Get(12):", value, ok) // 100 144 true
+	m.Delete("12")
+	_, ok = m.Get("12")
+	fmt.Println("After Delete Len:", m.Len(), "Get(12) found:", ok) // 99 false
+
+	// `go test -bench .` compares it with a single-lock map. Sharding only
+	// pays off when goroutines really run in parallel; on a single CPU the
+	// extra hashing makes it slightly slower.
+}
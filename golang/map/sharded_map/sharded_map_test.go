@@ -0,0 +1,110 @@
+package main
+
+import (
+	"strconv"
+	"sync"
+	"testing"
+)
+
+func TestShardedMapOperations(t *testing.T) {
+	for _, shardCount := range []int{-1, 0, 1, 8} {
+		m := NewShardedMap[string](shardCount)
+		if len(m.shards) < 1 {
+			t.Fatalf("NewShardedMap(%d) made %d shards, want at least 1", shardCount, len(m.shards))
+		}
+		m.Set("a", "1")
+		m.Set("b", "2")
+		m.Set("a", "3")
+		if got, ok := m.Get("a"); !ok || got != "3" {
+			t.Errorf("shards %d: Get(a) = %q, %v, want \"3\", true", shardCount, got, ok)
+		}
+		if _, ok := m.Get("missing"); ok {
+			t.Errorf("shards %d: Get(missing) found a value", shardCount)
+		}
+		m.Delete("a")
+		m.Delete("missing")
+		if _, ok := m.Get("a"); ok || m.Len() != 1 {
+			t.Errorf("shards %d: after Delete(a) found = %v, Len() = %d, want false, 1", shardCount, ok, m.Len())
+		}
+	}
+}
+
+// Run with -race to check the shards are locked properly
+func TestShardedMapConcurrentWriters(t *testing.T) {
+	m := NewShardedMap[int](8)
+	var wg sync.WaitGroup
+	for i := 0; i < 100; i++ {
+		wg.Add(1)
+		go func(n int) {
+			defer wg.Done()
+			key := strconv.Itoa(n)
+			m.Set(key, n*n)
+			m.Get(key)
+			m.Len()
+		}(i)
+	}
+	wg.Wait()
+
+	if m.Len() != 100 {
+		t.Errorf("Len() = %d, want 100", m.Len())
+	}
+	for i := 0; i < 100; i++ {
+		if got, ok := m.Get(strconv.Itoa(i)); !ok || got != i*i {
+			t.Errorf("Get(%d) = %d, %v, want %d, true", i, got, ok, i*i)
+		}
+	}
+}
+
+// mutexMap is the single-lock baseline for the benchmark
+type mutexMap[V any] struct {
+	mu    sync.RWMutex
+	items map[string]V
+}
+
+func (m *mutexMap[V]) Get(key string) (V, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	value, ok := m.items[key]
+	return value, ok
+}
+
+func (m *mutexMap[V]) Set(key string, value V) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.items[key] = value
+}
+
+type concurrentMap interface {
+	Get(key string) (int, bool)
+	Set(key string, value int)
+}
+
+var benchKeys = func() []string {
+	keys := make([]string, 1024)
+	for i := range keys {
+		keys[i] = "key-" + strconv.Itoa(i)
+	}
+	return keys
+}()
+
+// benchmarkMap runs a write-heavy mix (one Set for every three Gets) from
+// all CPUs at once
+func benchmarkMap(b *testing.B, m concurrentMap) {
+	b.RunParallel(func(pb *testing.PB) {
+		i := 0
+		for pb.Next() {
+			key := benchKeys[i%len(benchKeys)]
+			if i%4 == 0 {
+				m.Set(key, i)
+			} else {
+				m.Get(key)
+			}
+			i++
+		}
+	})
+}
+
+func BenchmarkShardedMap(b *testing.B) { benchmarkMap(b, NewShardedMap[int](32)) }
+func BenchmarkMutexMap(b *testing.B) {
+	benchmarkMap(b, &mutexMap[int]{items: make(map[string]int)})
+}
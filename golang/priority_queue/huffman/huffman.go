@@ -0,0 +1,119 @@
+package main
+
+import (
+	"container/heap"
+	"fmt"
+	"sort"
+)
+
+// HuffmanNode is a node of a Huffman tree. Leaves hold a symbol; internal
+// nodes hold the combined frequency of their subtree.
+type HuffmanNode struct {
+	Symbol      rune
+	Freq        int
+	Left, Right *HuffmanNode
+	minSymbol   rune // smallest symbol below this node, to break ties
+}
+
+func (n *HuffmanNode) isLeaf() bool {
+	return n.Left == nil && n.Right == nil
+}
+
+// huffmanQueue is a min-heap of nodes ordered by frequency
+type huffmanQueue []*HuffmanNode
+
+func (q huffmanQueue) Len() int { return len(q) }
+func (q huffmanQueue) Less(i, j int) bool {
+	if q[i].Freq != q[j].Freq {
+		return q[i].Freq < q[j].Freq
+	}
+	return q[i].minSymbol < q[j].minSymbol // keeps the tree the same on every run
+}
+func (q huffmanQueue) Swap(i, j int)       { q[i], q[j] = q[j], q[i] }
+func (q *huffmanQueue) Push(x interface{}) { *q = append(*q, x.(*HuffmanNode)) }
+func (q *huffmanQueue) Pop() interface{} {
+	old := *q
+	n := len(old)
+	node := old[n-1]
+	*q = old[:n-1]
+	return node
+}
+
+// BuildHuffmanTree builds a Huffman tree by repeatedly taking the two
+// lowest-frequency nodes off a min-heap and joining them under a new node,
+// until one node is left. It returns nil for an empty map.
+func BuildHuffmanTree(freq map[rune]int) *HuffmanNode {
+	queue := &huffmanQueue{}
+	for symbol, f := range freq {
+		*queue = append(*queue, &HuffmanNode{Symbol: symbol, Freq: f, minSymbol: symbol})
+	}
+	if queue.Len() == 0 {
+		return nil
+	}
+	heap.Init(queue)
+	for queue.Len() > 1 {
+		left := heap.Pop(queue).(*HuffmanNode)
+		right := heap.Pop(queue).(*HuffmanNode)
+		heap.Push(queue, &HuffmanNode{
+			Freq:      left.Freq + right.Freq,
+			Left:      left,
+			Right:     right,
+			minSymbol: min(left.minSymbol, right.minSymbol),
+		})
+	}
+	return heap.Pop(queue).(*HuffmanNode)
+}
+
+// HuffmanCodes returns the bit string for each symbol: "0" for a left branch
+// and "1" for a right one. A tree with a single symbol gives it the code "0".
+func HuffmanCodes(root *HuffmanNode) map[rune]string {
+	codes := make(map[rune]string)
+	if root == nil {
+		return codes
+	}
+	if root.isLeaf() {
+		codes[root.Symbol] = "0"
+		return codes
+	}
+	var walk func(n *HuffmanNode, code string)
+	walk = func(n *HuffmanNode, code string) {
+		if n.isLeaf() {
+			codes[n.Symbol] = code
+			return
+		}
+		walk(n.Left, code+"0")
+		walk(n.Right, code+"1")
+	}
+	walk(root, "")
+	return codes
+}
+
+func main() {
+	text := "abracadabra alakazam"
+	freq := make(map[rune]int)
+	for _, r := range text {
+		freq[r]++
+	}
+
+	codes := HuffmanCodes(BuildHuffmanTree(freq))
+	symbols := make([]rune, 0, len(codes))
+	for r := range codes {
+		symbols = append(symbols, r)
+	}
+	sort.Slice(symbols, func(i, j int) bool {
+		if freq[symbols[i]] != freq[symbols[j]] {
+			return freq[symbols[i]] > freq[symbols[j]]
+		}
+		return symbols[i] < symbols[j]
+	})
+
+	encodedBits := 0
+	for _, r := range symbols {
+		fmt.Printf("%q freq=%d code=%s\n", r, freq[r], codes[r])
+		encodedBits += freq[r] * len(codes[r])
+	}
+	fmt.Printf("encoded size: %d bits vs %d bits at 8 bits per rune\n", encodedBits, 8*len([]rune(text)))
+
+	fmt.Printf("single symbol: %q\n", HuffmanCodes(BuildHuffmanTree(map[rune]int{'x': 3}))['x'])
+	fmt.Println("empty:", HuffmanCodes(BuildHuffmanTree(nil)))
+}
@@ -0,0 +1,69 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+// frequencies counts the runes of text
+func frequencies(text string) map[rune]int {
+	freq := make(map[rune]int)
+	for _, r := range text {
+		freq[r]++
+	}
+	return freq
+}
+
+func TestHuffmanCodesArePrefixFree(t *testing.T) {
+	for _, text := range []string{"abracadabra alakazam", "aaaabbbccd", "the quick brown fox", "héllo wörld"} {
+		codes := HuffmanCodes(BuildHuffmanTree(frequencies(text)))
+		for a, codeA := range codes {
+			for b, codeB := range codes {
+				if a != b && strings.HasPrefix(codeB, codeA) {
+					t.Errorf("%q: code %s for %q is a prefix of %s for %q", text, codeA, a, codeB, b)
+				}
+			}
+		}
+	}
+}
+
+func TestHuffmanFrequentRunesGetShorterCodes(t *testing.T) {
+	freq := frequencies("abracadabra alakazam")
+	codes := HuffmanCodes(BuildHuffmanTree(freq))
+	if len(codes) != len(freq) {
+		t.Fatalf("got %d codes, want one per rune (%d)", len(codes), len(freq))
+	}
+	for a := range freq {
+		for b := range freq {
+			if freq[a] > freq[b] && len(codes[a]) > len(codes[b]) {
+				t.Errorf("%q (freq %d) has code %s, longer than %s for %q (freq %d)",
+					a, freq[a], codes[a], codes[b], b, freq[b])
+			}
+		}
+	}
+}
+
+func TestHuffmanKnownCodeLengths(t *testing.T) {
+	// a:8 b:4 c:2 d:1 e:1 builds a chain, one level per symbol
+	freq := map[rune]int{'a': 8, 'b': 4, 'c': 2, 'd': 1, 'e': 1}
+	codes := HuffmanCodes(BuildHuffmanTree(freq))
+	want := map[rune]int{'a': 1, 'b': 2, 'c': 3, 'd': 4, 'e': 4}
+	for r, length := range want {
+		if len(codes[r]) != length {
+			t.Errorf("code for %q = %s, want length %d", r, codes[r], length)
+		}
+	}
+}
+
+func TestHuffmanEdgeCases(t *testing.T) {
+	if root := BuildHuffmanTree(nil); root != nil {
+		t.Errorf("BuildHuffmanTree(nil) = %v, want nil", root)
+	}
+	if codes := HuffmanCodes(nil); len(codes) != 0 {
+		t.Errorf("HuffmanCodes(nil) = %v, want empty", codes)
+	}
+	codes := HuffmanCodes(BuildHuffmanTree(map[rune]int{'x': 3}))
+	if len(codes) != 1 || codes['x'] != "0" {
+		t.Errorf("single symbol codes = %v, want x -> 0", codes)
+	}
+}
@@ -3,6 +3,7 @@ package main
 import (
 	"container/heap"
 	"fmt"
+	"math"
 )
 
 type Item struct {
@@ -30,6 +31,90 @@ func (p *PriorityItemQueue) Pop() interface{} {
 	return item
 }
 
+// TopK returns the k highest-priority items, highest first. It keeps a
+// min-heap of at most k items whose root is the smallest of the current top k,
+// so each remaining item only needs comparing against the root: O(n log k)
+// rather than sorting everything. k <= 0 returns no items; k larger than
+// len(items) returns all of them.
+func TopK(items []Item, k int) []Item {
+	if k <= 0 {
+		return []Item{}
+	}
+	top := &PriorityItemQueue{} // Less orders by lowest priority first
+	for _, item := range items {
+		if top.Len() < k {
+			heap.Push(top, item)
+		} else if item.priority > (*top)[0].priority {
+			(*top)[0] = item
+			heap.Fix(top, 0)
+		}
+	}
+	result := make([]Item, top.Len())
+	for i := len(result) - 1; i >= 0; i-- {
+		result[i] = heap.Pop(top).(Item)
+	}
+	return result
+}
+
+// floatHeap is a heap of float64s; less decides whether it is a min or max heap
+type floatHeap struct {
+	values []float64
+	less   func(a, b float64) bool
+}
+
+func (h floatHeap) Len() int            { return len(h.values) }
+func (h floatHeap) Less(i, j int) bool  { return h.less(h.values[i], h.values[j]) }
+func (h floatHeap) Swap(i, j int)       { h.values[i], h.values[j] = h.values[j], h.values[i] }
+func (h *floatHeap) Push(x interface{}) { h.values = append(h.values, x.(float64)) }
+func (h *floatHeap) Pop() interface{} {
+	n := len(h.values)
+	x := h.values[n-1]
+	h.values = h.values[:n-1]
+	return x
+}
+
+// RunningMedian tracks the median of a stream of numbers. The lower half is
+// kept in a max-heap and the upper half in a min-heap, with the lower half
+// holding at most one extra value, so the median is always at the heap roots.
+type RunningMedian struct {
+	lower *floatHeap // max-heap
+	upper *floatHeap // min-heap
+}
+
+func NewRunningMedian() *RunningMedian {
+	return &RunningMedian{
+		lower: &floatHeap{less: func(a, b float64) bool { return a > b }},
+		upper: &floatHeap{less: func(a, b float64) bool { return a < b }},
+	}
+}
+
+// Add inserts x in O(log n)
+func (r *RunningMedian) Add(x float64) {
+	if r.lower.Len() == 0 || x <= r.lower.values[0] {
+		heap.Push(r.lower, x)
+	} else {
+		heap.Push(r.upper, x)
+	}
+	// Rebalance so len(lower) is len(upper) or len(upper)+1
+	if r.lower.Len() > r.upper.Len()+1 {
+		heap.Push(r.upper, heap.Pop(r.lower))
+	} else if r.upper.Len() > r.lower.Len() {
+		heap.Push(r.lower, heap.Pop(r.upper))
+	}
+}
+
+// Median returns the median of the values added so far, or NaN if there are none
+func (r *RunningMedian) Median() float64 {
+	switch {
+	case r.lower.Len() == 0:
+		return math.NaN()
+	case r.lower.Len() > r.upper.Len():
+		return r.lower.values[0]
+	default:
+		return (r.lower.values[0] + r.upper.values[0]) / 2
+	}
+}
+
 func main() {
 	priorityItemQueue := &PriorityItemQueue{}
 	heap.Init(priorityItemQueue)
@@ -47,4 +132,18 @@ func main() {
 	for priorityItemQueue.Len() > 0 {
 		fmt.Println(heap.Pop(priorityItemQueue).(Item))
 	}
+
+	items := []Item{{4, "four"}, {9, "nine"}, {1, "one"}, {7, "seven"}, {3, "three"}}
+	fmt.Println("TopK top 3:", TopK(items, 3)) // nine, seven, four
+	fmt.Println("TopK(k=0):", TopK(items, 0))
+
+	// Running median of a known stream
+	stream := []float64{5, 15, 1, 3, 8, 7, 9, 10}
+	want := []float64{5, 10, 5, 4, 5, 6, 7, 7.5}
+	median := NewRunningMedian()
+	fmt.Println("Median of empty stream:", median.Median())
+	for i, x := range stream {
+		median.Add(x)
+		fmt.Printf("Add(%g) -> median %g (want %g)\n", x, median.Median(), want[i])
+	}
 }
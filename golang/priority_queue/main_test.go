@@ -0,0 +1,67 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func TestTopKMatchesFullSort(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for trial := 0; trial < 20; trial++ {
+		items := make([]Item, rng.Intn(200))
+		for i := range items {
+			items[i] = Item{rng.Float64() * 100, fmt.Sprintf("item %d", i)}
+		}
+		sorted := append([]Item(nil), items...)
+		sort.Slice(sorted, func(i, j int) bool { return sorted[i].priority > sorted[j].priority })
+
+		for _, k := range []int{1, 5, len(items), len(items) + 10} {
+			want := append([]Item{}, sorted[:min(k, len(sorted))]...)
+			if got := TopK(items, k); !reflect.DeepEqual(got, want) {
+				t.Errorf("trial %d: TopK(%d items, k=%d) = %v, want %v", trial, len(items), k, got, want)
+			}
+		}
+	}
+}
+
+func TestTopKNonPositiveK(t *testing.T) {
+	items := []Item{{1, "a"}, {2, "b"}}
+	for _, k := range []int{0, -1} {
+		if got := TopK(items, k); got == nil || len(got) != 0 {
+			t.Errorf("TopK(k=%d) = %#v, want an empty slice", k, got)
+		}
+	}
+}
+
+func TestRunningMedian(t *testing.T) {
+	stream := []float64{5, 15, 1, 3, 8, 7, 9, 10}
+	want := []float64{5, 10, 5, 4, 5, 6, 7, 7.5}
+
+	median := NewRunningMedian()
+	if got := median.Median(); !math.IsNaN(got) {
+		t.Errorf("Median() of empty stream = %v, want NaN", got)
+	}
+	for i, x := range stream {
+		median.Add(x)
+		if got := median.Median(); got != want[i] {
+			t.Errorf("after Add(%g): Median() = %g, want %g", x, got, want[i])
+		}
+	}
+}
+
+func TestRunningMedianDuplicatesAndNegatives(t *testing.T) {
+	stream := []float64{-1, -1, -1, 4, -3}
+	want := []float64{-1, -1, -1, -1, -1}
+
+	median := NewRunningMedian()
+	for i, x := range stream {
+		median.Add(x)
+		if got := median.Median(); got != want[i] {
+			t.Errorf("after Add(%g): Median() = %g, want %g", x, got, want[i])
+		}
+	}
+}
@@ -0,0 +1,147 @@
+package main
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// FixedWindowLimiter allows up to limit requests per window, where windows are
+// fixed wall-clock intervals (e.g. each whole second) and the count resets at
+// every boundary. It needs only a counter, but a client can send limit
+// requests just before a boundary and limit more just after it, so up to
+// 2*limit requests can pass within a single window's length.
+type FixedWindowLimiter struct {
+	mu          sync.Mutex
+	limit       int
+	window      time.Duration
+	windowStart time.Time
+	count       int
+	now         func() time.Time // replaceable so demos don't have to sleep
+}
+
+// NewFixedWindowLimiter creates a limiter allowing limit requests per window
+func NewFixedWindowLimiter(limit int, window time.Duration) *FixedWindowLimiter {
+	return &FixedWindowLimiter{limit: limit, window: window, now: time.Now}
+}
+
+// Allow reports whether a request may proceed now, counting it if so
+func (l *FixedWindowLimiter) Allow() bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	start := l.now().Truncate(l.window)
+	if !start.Equal(l.windowStart) {
+		l.windowStart = start
+		l.count = 0
+	}
+	if l.count >= l.limit {
+		return false
+	}
+	l.count++
+	return true
+}
+
+// SlidingWindowLimiter allows up to limit requests in any window-long span
+// ending now. It remembers the time of every allowed request, trading memory
+// for the guarantee the fixed window cannot give.
+type SlidingWindowLimiter struct {
+	mu     sync.Mutex
+	limit  int
+	window time.Duration
+	log    []time.Time // times of allowed requests, oldest first
+	now    func() time.Time
+}
+
+// NewSlidingWindowLimiter creates a limiter allowing limit requests in any
+// window-long span
+func NewSlidingWindowLimiter(limit int, window time.Duration) *SlidingWindowLimiter {
+	return &SlidingWindowLimiter{limit: limit, window: window, now: time.Now}
+}
+
+// Allow reports whether a request may proceed now, recording it if so
+func (l *SlidingWindowLimiter) Allow() bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	cutoff := now.Add(-l.window)
+	expired := 0
+	for expired < len(l.log) && !l.log[expired].After(cutoff) {
+		expired++
+	}
+	l.log = l.log[expired:]
+	if len(l.log) >= l.limit {
+		return false
+	}
+	l.log = append(l.log, now)
+	return true
+}
+
+// fakeClock is a manually advanced clock for the demo
+type fakeClock struct {
+	mu sync.Mutex
+	t  time.Time
+}
+
+func (f *fakeClock) Now() time.Time {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.t
+}
+
+func (f *fakeClock) Advance(d time.Duration) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.t = f.t.Add(d)
+}
+
+// burst sends n requests through allow and returns how many passed
+func burst(allow func() bool, n int) int {
+	passed := 0
+	for i := 0; i < n; i++ {
+		if allow() {
+			passed++
+		}
+	}
+	return passed
+}
+
+func main() {
+	const limit = 5
+	const window = time.Second
+
+	// Within one window the fixed window behaves as expected
+	clock := &fakeClock{t: time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)}
+	fixed := NewFixedWindowLimiter(limit, window)
+	fixed.now = clock.Now
+	fmt.Println("Fixed window, 8 requests in one window:", burst(fixed.Allow, 8), "allowed") // 5
+	clock.Advance(window)
+	fmt.Println("Fixed window, next window:", burst(fixed.Allow, 8), "allowed") // 5
+
+	// Boundary burst: send a burst 100ms before a boundary and another 100ms
+	// after it. Both land in different fixed windows, so 2*limit requests
+	// pass within 200ms. The sliding window still sees them as one span.
+	start := time.Date(2024, 1, 1, 12, 0, 0, 900_000_000, time.UTC)
+	fixedClock := &fakeClock{t: start}
+	slidingClock := &fakeClock{t: start}
+	fixed = NewFixedWindowLimiter(limit, window)
+	fixed.now = fixedClock.Now
+	sliding := NewSlidingWindowLimiter(limit, window)
+	sliding.now = slidingClock.Now
+
+	fixedPassed := burst(fixed.Allow, limit)
+	slidingPassed := burst(sliding.Allow, limit)
+	fixedClock.Advance(200 * time.Millisecond)
+	slidingClock.Advance(200 * time.Millisecond)
+	fixedPassed += burst(fixed.Allow, limit)
+	slidingPassed += burst(sliding.Allow, limit)
+
+	fmt.Printf("Across a boundary within 200ms: fixed window allowed %d, sliding window allowed %d\n",
+		fixedPassed, slidingPassed) // 10 5
+
+	// Once a full window has passed since the first burst the sliding window
+	// lets requests through again
+	slidingClock.Advance(window)
+	fmt.Println("Sliding window after a full window:", burst(sliding.Allow, limit), "allowed") // 5
+}
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+const (
+	testLimit  = 5
+	testWindow = time.Second
+)
+
+// newTestLimiters returns a fixed and a sliding window limiter sharing a fake
+// clock that starts at start
+func newTestLimiters(start time.Time) (*FixedWindowLimiter, *SlidingWindowLimiter, *fakeClock) {
+	clock := &fakeClock{t: start}
+	fixed := NewFixedWindowLimiter(testLimit, testWindow)
+	fixed.now = clock.Now
+	sliding := NewSlidingWindowLimiter(testLimit, testWindow)
+	sliding.now = clock.Now
+	return fixed, sliding, clock
+}
+
+func TestLimitersWithinOneWindow(t *testing.T) {
+	fixed, sliding, clock := newTestLimiters(time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC))
+
+	if got := burst(fixed.Allow, 8); got != testLimit {
+		t.Errorf("fixed window allowed %d of 8, want %d", got, testLimit)
+	}
+	if got := burst(sliding.Allow, 8); got != testLimit {
+		t.Errorf("sliding window allowed %d of 8, want %d", got, testLimit)
+	}
+
+	clock.Advance(testWindow)
+	if got := burst(fixed.Allow, 8); got != testLimit {
+		t.Errorf("fixed window allowed %d in the next window, want %d", got, testLimit)
+	}
+	if got := burst(sliding.Allow, 8); got != testLimit {
+		t.Errorf("sliding window allowed %d a window later, want %d", got, testLimit)
+	}
+}
+
+// The fixed window's weakness: a burst just before a boundary and another
+// just after it both pass, so 2N requests get through in 200ms. The sliding
+// window sees both bursts in one window-long span and allows only N.
+func TestFixedWindowBoundaryBurst(t *testing.T) {
+	start := time.Date(2024, 1, 1, 12, 0, 0, 900_000_000, time.UTC)
+	fixed, sliding, clock := newTestLimiters(start)
+
+	fixedPassed := burst(fixed.Allow, testLimit)
+	slidingPassed := burst(sliding.Allow, testLimit)
+	clock.Advance(200 * time.Millisecond) // now past the 12:00:01 boundary
+	fixedPassed += burst(fixed.Allow, testLimit)
+	slidingPassed += burst(sliding.Allow, testLimit)
+
+	if fixedPassed != 2*testLimit {
+		t.Errorf("fixed window allowed %d across the boundary, want 2N = %d", fixedPassed, 2*testLimit)
+	}
+	if slidingPassed != testLimit {
+		t.Errorf("sliding window allowed %d across the boundary, want N = %d", slidingPassed, testLimit)
+	}
+
+	// A full window after the first burst the sliding window opens again
+	clock.Advance(testWindow - 200*time.Millisecond)
+	if got := burst(sliding.Allow, testLimit); got != testLimit {
+		t.Errorf("sliding window allowed %d a window after the first burst, want %d", got, testLimit)
+	}
+}
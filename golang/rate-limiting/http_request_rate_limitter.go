@@ -3,51 +3,117 @@ package main
 import (
 	"fmt"
 	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
 	"time"
 )
 
-// SimpleRateLimiter uses a buffered channel to limit concurrent requests
+// SimpleRateLimiter limits how many requests run at once. A condition variable
+// is used rather than a buffered channel so the limit can change at runtime.
 type SimpleRateLimiter struct {
-	limiter chan struct{}
-	client  *http.Client
+	mu       sync.Mutex
+	cond     *sync.Cond
+	limit    int
+	inFlight int
+	client   *http.Client
 }
 
-// NewSimpleRateLimiter creates a rate limiter with a specified concurrency limit
-func NewSimpleRateLimiter(limit int) *SimpleRateLimiter {
-	return &SimpleRateLimiter{
-		limiter: make(chan struct{}, limit), // Buffered channel with limit
-		client: &http.Client{
+// NewSimpleRateLimiter creates a rate limiter with a specified concurrency limit.
+// A limit below 1 is treated as 1, since it would block every request forever.
+// Requests are sent with client; nil uses a client with a 10 second timeout.
+func NewSimpleRateLimiter(limit int, client *http.Client) *SimpleRateLimiter {
+	if client == nil {
+		client = &http.Client{
 			Timeout: 10 * time.Second,
-		},
+		}
 	}
+	rl := &SimpleRateLimiter{
+		limit:  max(limit, 1),
+		client: client,
+	}
+	rl.cond = sync.NewCond(&rl.mu)
+	return rl
+}
+
+// acquire blocks until fewer than limit requests are in flight
+func (rl *SimpleRateLimiter) acquire() {
+	rl.mu.Lock()
+	for rl.inFlight >= rl.limit {
+		rl.cond.Wait()
+	}
+	rl.inFlight++
+	rl.mu.Unlock()
+}
+
+func (rl *SimpleRateLimiter) release() {
+	rl.mu.Lock()
+	rl.inFlight--
+	rl.mu.Unlock()
+	rl.cond.Signal() // one slot freed, so wake one waiter
+}
+
+// SetLimit changes the concurrency limit. Raising it lets waiting requests
+// start straight away. Lowering it never interrupts requests already in
+// flight; new requests wait until enough of them have finished. Like
+// NewSimpleRateLimiter, a limit below 1 is treated as 1.
+func (rl *SimpleRateLimiter) SetLimit(n int) {
+	rl.mu.Lock()
+	rl.limit = max(n, 1)
+	rl.mu.Unlock()
+	rl.cond.Broadcast() // several waiters may fit under a higher limit
+}
+
+// InFlight returns the number of requests currently running
+func (rl *SimpleRateLimiter) InFlight() int {
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+	return rl.inFlight
 }
 
 // MakeRequest performs a rate-limited HTTP request
 func (rl *SimpleRateLimiter) MakeRequest(url string) (*http.Response, error) {
-	rl.limiter <- struct{}{} // Acquire token
-	defer func() {
-		<-rl.limiter // Release token after request completes
-	}()
+	rl.acquire()       // Acquire token
+	defer rl.release() // Release token after request completes
 
 	fmt.Printf("Making request to %s at %v\n", url, time.Now().Format("15:04:05"))
 	return rl.client.Get(url)
 }
 
+// newConcurrencyServer returns a local test server that holds each request for
+// delay and records the most requests it has seen in flight at once
+func newConcurrencyServer(delay time.Duration) (*httptest.Server, *atomic.Int64) {
+	var inFlight, maxInFlight atomic.Int64
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		current := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			seen := maxInFlight.Load()
+			if current <= seen || maxInFlight.CompareAndSwap(seen, current) {
+				break
+			}
+		}
+		time.Sleep(delay)
+		w.WriteHeader(http.StatusOK)
+	}))
+	return server, &maxInFlight
+}
+
 func main() {
+	// A local server instead of a real API, so the run is deterministic
+	server, maxInFlight := newConcurrencyServer(100 * time.Millisecond)
+	defer server.Close()
+
 	// Create a rate limiter with concurrent limit of 2
-	limiter := NewSimpleRateLimiter(2)
-
-	// Example URLs to test
-	urls := []string{
-		"https://api.github.com",
-		"https://api.github.com/users",
-		"https://api.github.com/repos",
-		"https://api.github.com/gists",
-	}
+	const limit = 2
+	limiter := NewSimpleRateLimiter(limit, server.Client())
 
 	// Make multiple requests
-	for i, url := range urls {
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
 		go func(requestID int, requestURL string) {
+			defer wg.Done()
 			fmt.Printf("Request %d waiting for rate limiter...\n", requestID)
 
 			resp, err := limiter.MakeRequest(requestURL)
@@ -59,11 +125,18 @@ func main() {
 
 			fmt.Printf("Request %d completed with status: %s\n",
 				requestID, resp.Status)
-		}(i+1, url)
+		}(i+1, fmt.Sprintf("%s/item/%d", server.URL, i+1))
 	}
 
-	// Wait to see the results
-	time.Sleep(5 * time.Second)
+	wg.Wait()
+	fmt.Printf("Max concurrent requests seen by server: %d (limit %d, within limit: %v)\n",
+		maxInFlight.Load(), limit, maxInFlight.Load() <= limit)
+
+	// A zero limit would block forever, so it is raised to 1
+	zero := NewSimpleRateLimiter(0, server.Client())
+	zero.SetLimit(-1)
+	resp, err := zero.MakeRequest(server.URL)
+	handleResponse(resp, err)
 }
 
 // Helper function to demonstrate error handling
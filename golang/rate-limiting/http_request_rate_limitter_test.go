@@ -0,0 +1,89 @@
+package main
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+// fire sends n concurrent requests through limiter and waits for all of them
+func fire(t *testing.T, limiter *SimpleRateLimiter, url string, n int) {
+	t.Helper()
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			resp, err := limiter.MakeRequest(url)
+			if err != nil {
+				t.Errorf("MakeRequest: %v", err)
+				return
+			}
+			resp.Body.Close()
+		}()
+	}
+	wg.Wait()
+}
+
+func TestSimpleRateLimiterBoundsConcurrency(t *testing.T) {
+	for _, limit := range []int{1, 2, 5} {
+		server, maxInFlight := newConcurrencyServer(20 * time.Millisecond)
+		limiter := NewSimpleRateLimiter(limit, server.Client())
+
+		fire(t, limiter, server.URL, 12)
+		server.Close()
+
+		if got := maxInFlight.Load(); got > int64(limit) {
+			t.Errorf("limit %d: server saw %d requests at once", limit, got)
+		}
+		if got := limiter.InFlight(); got != 0 {
+			t.Errorf("limit %d: InFlight() = %d after all requests finished, want 0", limit, got)
+		}
+	}
+}
+
+func TestSimpleRateLimiterZeroLimit(t *testing.T) {
+	server, maxInFlight := newConcurrencyServer(0)
+	defer server.Close()
+
+	// A limit below 1 would block every request, so it is raised to 1
+	limiter := NewSimpleRateLimiter(0, server.Client())
+	limiter.SetLimit(-1)
+	fire(t, limiter, server.URL, 3)
+	if got := maxInFlight.Load(); got != 1 {
+		t.Errorf("server saw %d requests at once, want 1", got)
+	}
+}
+
+func TestSetLimitLowersConcurrencyUnderLoad(t *testing.T) {
+	const delay = 100 * time.Millisecond
+	server, _ := newConcurrencyServer(delay)
+	defer server.Close()
+	limiter := NewSimpleRateLimiter(4, server.Client())
+
+	done := make(chan struct{})
+	go func() {
+		fire(t, limiter, server.URL, 20)
+		close(done)
+	}()
+
+	time.Sleep(delay / 2)
+	if got := limiter.InFlight(); got != 4 {
+		t.Errorf("InFlight() before SetLimit(1) = %d, want 4", got)
+	}
+	limiter.SetLimit(1)
+	time.Sleep(2 * delay) // let the requests started under the old limit finish
+
+	maxAfter := 0
+	for {
+		select {
+		case <-done:
+			if maxAfter > 1 {
+				t.Errorf("max in flight after lowering the limit = %d, want at most 1", maxAfter)
+			}
+			return
+		case <-time.After(5 * time.Millisecond):
+			maxAfter = max(maxAfter, limiter.InFlight())
+		}
+	}
+}
@@ -0,0 +1,121 @@
+package main
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// LeakyBucketLimiter models a bucket that holds up to capacity queued
+// requests and leaks one every interval. Bursts are accepted while there is
+// room and released at a steady rate; once the bucket is full extra requests
+// are rejected. A token bucket, by contrast, lets a saved-up burst through
+// at once.
+type LeakyBucketLimiter struct {
+	queue     chan struct{}
+	out       chan time.Time
+	done      chan struct{}
+	stopped   chan struct{}
+	closeOnce sync.Once
+}
+
+// NewLeakyBucketLimiter creates a bucket holding capacity requests that
+// drains one request every interval
+func NewLeakyBucketLimiter(capacity int, interval time.Duration) *LeakyBucketLimiter {
+	lb := &LeakyBucketLimiter{
+		queue:   make(chan struct{}, capacity),
+		out:     make(chan time.Time),
+		done:    make(chan struct{}),
+		stopped: make(chan struct{}),
+	}
+	go lb.drain(interval)
+	return lb
+}
+
+// drain releases one queued request per tick. Sending on out blocks until a
+// consumer is ready, so a slow consumer slows the leak instead of losing requests.
+func (lb *LeakyBucketLimiter) drain(interval time.Duration) {
+	defer close(lb.stopped)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-lb.done:
+			return
+		case <-ticker.C:
+		}
+		select {
+		case <-lb.queue:
+		default:
+			continue // bucket is empty, nothing leaks this tick
+		}
+		select {
+		case lb.out <- time.Now():
+		case <-lb.done:
+			return
+		}
+	}
+}
+
+// Submit adds a request to the bucket, returning false if the bucket is full
+func (lb *LeakyBucketLimiter) Submit() bool {
+	select {
+	case lb.queue <- struct{}{}:
+		return true
+	default:
+		return false
+	}
+}
+
+// Out delivers the time each queued request leaks out of the bucket
+func (lb *LeakyBucketLimiter) Out() <-chan time.Time {
+	return lb.out
+}
+
+// Close stops the drain goroutine and waits for it to exit. Requests still in
+// the bucket are dropped. Close is safe to call more than once.
+func (lb *LeakyBucketLimiter) Close() {
+	lb.closeOnce.Do(func() { close(lb.done) })
+	<-lb.stopped
+}
+
+func main() {
+	const capacity = 4
+	const interval = 50 * time.Millisecond
+	const burst = 10
+
+	limiter := NewLeakyBucketLimiter(capacity, interval)
+	defer limiter.Close()
+
+	// A burst larger than the bucket: the first capacity requests are queued
+	// and the rest overflow
+	accepted, rejected := 0, 0
+	for i := 0; i < burst; i++ {
+		if limiter.Submit() {
+			accepted++
+		} else {
+			rejected++
+		}
+	}
+	fmt.Printf("Burst of %d: accepted %d, rejected %d\n", burst, accepted, rejected) // 4 6
+
+	// The accepted requests come out one interval apart rather than all at once
+	start := time.Now()
+	var previous time.Time
+	for i := 0; i < accepted; i++ {
+		leaked := <-limiter.Out()
+		if i == 0 {
+			fmt.Printf("Request %d leaked after %v\n", i+1, leaked.Sub(start).Round(10*time.Millisecond))
+		} else {
+			fmt.Printf("Request %d leaked %v after the previous one\n", i+1, leaked.Sub(previous).Round(10*time.Millisecond))
+		}
+		previous = leaked
+	}
+
+	// The bucket has drained, so there is room again
+	fmt.Println("Submit after draining:", limiter.Submit()) // true
+	limiter.Close()
+	limiter.Close() // second Close is a no-op
+	fmt.Println("Closed")
+}
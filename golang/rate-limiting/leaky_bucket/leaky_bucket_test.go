@@ -0,0 +1,75 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+// submitBurst submits n requests at once and counts how many were rejected
+func submitBurst(lb *LeakyBucketLimiter, n int) (rejected int) {
+	for i := 0; i < n; i++ {
+		if !lb.Submit() {
+			rejected++
+		}
+	}
+	return rejected
+}
+
+func TestLeakyBucketRejectsOverflow(t *testing.T) {
+	tests := []struct {
+		capacity, burst, wantRejected int
+	}{
+		{capacity: 4, burst: 10, wantRejected: 6},
+		{capacity: 4, burst: 4, wantRejected: 0},
+		{capacity: 1, burst: 3, wantRejected: 2},
+		{capacity: 0, burst: 2, wantRejected: 2},
+	}
+	for _, tt := range tests {
+		// An hour-long interval means nothing leaks during the burst
+		lb := NewLeakyBucketLimiter(tt.capacity, time.Hour)
+		if got := submitBurst(lb, tt.burst); got != tt.wantRejected {
+			t.Errorf("capacity %d, burst %d: rejected %d, want %d", tt.capacity, tt.burst, got, tt.wantRejected)
+		}
+		lb.Close()
+	}
+}
+
+func TestLeakyBucketDrainsAtSteadyRate(t *testing.T) {
+	const interval = 20 * time.Millisecond
+	lb := NewLeakyBucketLimiter(3, interval)
+	defer lb.Close()
+
+	if got := submitBurst(lb, 5); got != 2 {
+		t.Fatalf("rejected %d of a burst of 5, want 2", got)
+	}
+	previous := <-lb.Out()
+	for i := 1; i < 3; i++ {
+		leaked := <-lb.Out()
+		// Ticks can be delivered a little late, never much early
+		if gap := leaked.Sub(previous); gap < interval/2 {
+			t.Errorf("request %d leaked %v after the previous one, want about %v", i+1, gap, interval)
+		}
+		previous = leaked
+	}
+
+	// Once drained there is room again
+	if !lb.Submit() {
+		t.Error("Submit after draining = false, want true")
+	}
+}
+
+func TestLeakyBucketCloseIsIdempotent(t *testing.T) {
+	lb := NewLeakyBucketLimiter(2, time.Millisecond)
+	lb.Submit()
+	done := make(chan struct{})
+	go func() {
+		lb.Close()
+		lb.Close()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Close blocked with a request waiting to leak")
+	}
+}
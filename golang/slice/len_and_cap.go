@@ -2,6 +2,77 @@ package main
 
 import "fmt"
 
+// PredictCap returns the capacity a slice with capacity currentCap would have
+// after an append that needs room for needed elements, following the growth
+// rules documented in main:
+//   - if needed fits, the capacity doesn't change
+//   - if needed is more than double the current capacity, it is used directly
+//   - below 1024 the capacity doubles
+//   - from 1024 on it grows by 1.25x until needed fits
+//
+// This is the classic policy. Since Go 1.18 the runtime switches at 256
+// elements with a smoother formula, and it rounds every allocation up to a
+// memory size class, so real capacities are often somewhat larger; see
+// comparePredictCap for the difference on this machine.
+func PredictCap(currentCap, needed int) int {
+	if needed <= currentCap {
+		return currentCap
+	}
+	doubled := currentCap * 2
+	if needed > doubled {
+		return needed
+	}
+	if currentCap < 1024 {
+		return doubled
+	}
+	newCap := currentCap
+	for newCap < needed {
+		newCap += newCap / 4
+	}
+	return newCap
+}
+
+// DeleteAt returns a new slice without the element at index i. The input's
+// backing array is left untouched, unlike the usual
+// append(s[:i], s[i+1:]...), which shifts the tail of s in place and so
+// corrupts every other slice sharing that array. An out-of-range index
+// returns s unchanged.
+func DeleteAt[T any](s []T, i int) []T {
+	if i < 0 || i >= len(s) {
+		return s
+	}
+	result := make([]T, 0, len(s)-1)
+	result = append(result, s[:i]...)
+	return append(result, s[i+1:]...)
+}
+
+// InsertAt returns a new slice with v inserted before index i; i == len(s)
+// appends at the end. Like DeleteAt it never writes to the input's backing
+// array, and an out-of-range index returns s unchanged.
+func InsertAt[T any](s []T, i int, v T) []T {
+	if i < 0 || i > len(s) {
+		return s
+	}
+	result := make([]T, 0, len(s)+1)
+	result = append(result, s[:i]...)
+	result = append(result, v)
+	return append(result, s[i:]...)
+}
+
+// comparePredictCap appends one element at a time and prints every
+// reallocation next to the capacity PredictCap expected
+func comparePredictCap() {
+	var s []int
+	for i := 0; i < 3000; i++ {
+		oldCap := cap(s)
+		predicted := PredictCap(oldCap, len(s)+1)
+		s = append(s, i)
+		if cap(s) != oldCap {
+			fmt.Printf("grow %5d -> actual %5d, predicted %5d\n", oldCap, cap(s), predicted)
+		}
+	}
+}
+
 func main() {
 
 	//The zero value of a slice is nil. The len and cap functions will both return 0 for a nil slice.
@@ -69,4 +140,15 @@ func main() {
 		fmt.Printf("Len: %d, Cap: %d\n", len(s), cap(s))
 	}	
 
+	comparePredictCap()
+
+	// The in-place delete idiom overwrites the original through the shared array
+	original := []string{"a", "b", "c", "d"}
+	_ = append(original[:1], original[2:]...)
+	fmt.Println(original) // output: [a c d d]
+
+	original = []string{"a", "b", "c", "d"}
+	fmt.Println(DeleteAt(original, 1), original)      // output: [a c d] [a b c d]
+	fmt.Println(InsertAt(original, 2, "x"), original) // output: [a b x c d] [a b c d]
+
 }
@@ -0,0 +1,144 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestPredictCapPolicy(t *testing.T) {
+	tests := []struct {
+		name               string
+		currentCap, needed int
+		want               int
+	}{
+		{"fits", 8, 5, 8},
+		{"exactly full", 8, 8, 8},
+		{"nil slice", 0, 1, 1},
+		{"doubles below 1024", 4, 5, 8},
+		{"doubles just below 1024", 1000, 1001, 2000},
+		{"more than double uses needed", 4, 20, 20},
+		{"1.25x at 1024", 1024, 1025, 1280},
+		{"1.25x repeatedly", 1024, 1700, 2000},
+		{"large append uses needed", 2048, 5000, 5000},
+	}
+	for _, tt := range tests {
+		if got := PredictCap(tt.currentCap, tt.needed); got != tt.want {
+			t.Errorf("%s: PredictCap(%d, %d) = %d, want %d", tt.name, tt.currentCap, tt.needed, got, tt.want)
+		}
+	}
+}
+
+// TestPredictCapAgainstAppend grows a slice one element at a time and
+// compares each reallocation with PredictCap.
+//
+// The runtime only follows the classic policy for small slices. Since Go
+// 1.18 it leaves pure doubling at 256 elements rather than 1024 and then
+// grows by a formula that moves smoothly from 2x towards 1.25x, and every
+// capacity is rounded up to an allocator size class. Recent runtimes also
+// give the first allocation of a nil slice a few elements of headroom. So
+// the predictions must match exactly from 1 up to 256, and elsewhere the
+// test only checks that the real capacity is in the range either policy
+// could produce.
+func TestPredictCapAgainstAppend(t *testing.T) {
+	var s []int
+	for i := 0; i < 5000; i++ {
+		oldCap := cap(s)
+		predicted := PredictCap(oldCap, len(s)+1)
+		s = append(s, i)
+		actual := cap(s)
+		if actual == oldCap {
+			continue
+		}
+
+		if oldCap == 0 {
+			if actual < predicted {
+				t.Errorf("first append: cap %d, want at least %d", actual, predicted)
+			}
+			continue
+		}
+		if oldCap < 256 {
+			if actual != predicted {
+				t.Errorf("grow from %d: cap %d, predicted %d", oldCap, actual, predicted)
+			}
+			continue
+		}
+		if actual != predicted {
+			t.Logf("grow from %d: cap %d, predicted %d (runtime formula diverges)", oldCap, actual, predicted)
+		}
+		if actual < oldCap+oldCap/4 || actual > 2*oldCap+oldCap/4 {
+			t.Errorf("grow from %d: cap %d, want between 1.25x and about 2x", oldCap, actual)
+		}
+	}
+}
+
+func TestDeleteAtLeavesOriginal(t *testing.T) {
+	original := []string{"a", "b", "c", "d"}
+	alias := original[:2] // shares the backing array
+
+	for i, want := range [][]string{
+		{"b", "c", "d"},
+		{"a", "c", "d"},
+		{"a", "b", "d"},
+		{"a", "b", "c"},
+	} {
+		if got := DeleteAt(original, i); !reflect.DeepEqual(got, want) {
+			t.Errorf("DeleteAt(%d) = %v, want %v", i, got, want)
+		}
+	}
+	if want := []string{"a", "b", "c", "d"}; !reflect.DeepEqual(original, want) {
+		t.Errorf("original = %v after DeleteAt, want %v", original, want)
+	}
+	if want := []string{"a", "b"}; !reflect.DeepEqual(alias, want) {
+		t.Errorf("alias = %v after DeleteAt, want %v", alias, want)
+	}
+
+	// Writing to the result must not reach the original either
+	result := DeleteAt(original, 0)
+	result[0] = "x"
+	if original[1] != "b" {
+		t.Errorf("original[1] = %q after writing to the result, want %q", original[1], "b")
+	}
+}
+
+func TestInsertAtLeavesOriginal(t *testing.T) {
+	// Spare capacity is where an in-place insert would write
+	original := make([]int, 3, 10)
+	copy(original, []int{1, 2, 3})
+
+	tests := []struct {
+		i    int
+		want []int
+	}{
+		{0, []int{9, 1, 2, 3}},
+		{1, []int{1, 9, 2, 3}},
+		{3, []int{1, 2, 3, 9}},
+	}
+	for _, tt := range tests {
+		if got := InsertAt(original, tt.i, 9); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("InsertAt(%d) = %v, want %v", tt.i, got, tt.want)
+		}
+	}
+	if want := []int{1, 2, 3}; !reflect.DeepEqual(original, want) {
+		t.Errorf("original = %v after InsertAt, want %v", original, want)
+	}
+	if spare := original[:4][3]; spare != 0 {
+		t.Errorf("InsertAt wrote %d into the original's spare capacity", spare)
+	}
+}
+
+func TestDeleteInsertOutOfRange(t *testing.T) {
+	s := []int{1, 2, 3}
+	for _, i := range []int{-1, 3, 10} {
+		if got := DeleteAt(s, i); !reflect.DeepEqual(got, s) {
+			t.Errorf("DeleteAt(%d) = %v, want the input unchanged", i, got)
+		}
+	}
+	for _, i := range []int{-1, 4} {
+		if got := InsertAt(s, i, 9); !reflect.DeepEqual(got, s) {
+			t.Errorf("InsertAt(%d) = %v, want the input unchanged", i, got)
+		}
+	}
+	if got := DeleteAt([]int{}, 0); len(got) != 0 {
+		t.Errorf("DeleteAt on an empty slice = %v, want empty", got)
+	}
+}
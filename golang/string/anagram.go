@@ -0,0 +1,50 @@
+package main
+
+import (
+	"fmt"
+	"unicode"
+)
+
+// CharFrequency counts how many times each character (rune) appears in s.
+// Multi-byte characters such as "é" count once, not once per byte.
+func CharFrequency(s string) map[rune]int {
+	freq := make(map[rune]int)
+	for _, r := range s {
+		freq[r]++
+	}
+	return freq
+}
+
+// AreAnagrams reports whether a and b use the same characters the same number
+// of times, ignoring case and whitespace. It compares runes, so "é" matches
+// "É" and is never confused with the bytes of another character.
+func AreAnagrams(a, b string) bool {
+	counts := make(map[rune]int)
+	for _, r := range a {
+		if !unicode.IsSpace(r) {
+			counts[unicode.ToLower(r)]++
+		}
+	}
+	for _, r := range b {
+		if unicode.IsSpace(r) {
+			continue
+		}
+		r = unicode.ToLower(r)
+		counts[r]--
+		if counts[r] < 0 {
+			return false
+		}
+	}
+	for _, c := range counts {
+		if c != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+func main() {
+	fmt.Println(AreAnagrams("Dormitory", "dirty room")) // true
+	fmt.Println(AreAnagrams("résumé", "éésumr"))        // true
+	fmt.Println(CharFrequency("héllo"))
+}
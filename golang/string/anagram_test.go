@@ -0,0 +1,47 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestAreAnagrams(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want bool
+	}{
+		{"listen", "silent", true},
+		{"Dormitory", "dirty room", true},
+		{"hello", "world", false},
+		{"", "", true},
+		{"", " ", true},
+		{"a", "", false},
+		{"résumé", "éésumr", true},
+		{"Éclair", "clairé", true},
+		{"é", "e", false},
+		{"aab", "abb", false},
+		// Same byte length, different runes: "é" is two bytes, "ab" is two runes
+		{"é", "ab", false},
+	}
+	for _, tt := range tests {
+		if got := AreAnagrams(tt.a, tt.b); got != tt.want {
+			t.Errorf("AreAnagrams(%q, %q) = %v, want %v", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestCharFrequency(t *testing.T) {
+	tests := []struct {
+		s    string
+		want map[rune]int
+	}{
+		{"", map[rune]int{}},
+		{"aab", map[rune]int{'a': 2, 'b': 1}},
+		{"héllo", map[rune]int{'h': 1, 'é': 1, 'l': 2, 'o': 1}},
+	}
+	for _, tt := range tests {
+		if got := CharFrequency(tt.s); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("CharFrequency(%q) = %v, want %v", tt.s, got, tt.want)
+		}
+	}
+}
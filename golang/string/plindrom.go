@@ -14,9 +14,37 @@ func isPalindrome(s string) bool {
 	}
 	return true
 }
+// LongestPalindrome returns the longest palindromic substring of s, working on
+// runes so multi-byte characters stay whole. Each rune (odd lengths) and each
+// gap between runes (even lengths) is tried as a center and expanded outwards,
+// which is O(n²). When several palindromes share the longest length, the first
+// one is returned.
+func LongestPalindrome(s string) string {
+	runes := []rune(s)
+	start, length := 0, 0
+	expand := func(left, right int) {
+		for left >= 0 && right < len(runes) && runes[left] == runes[right] {
+			left--
+			right++
+		}
+		if n := right - left - 1; n > length {
+			start, length = left+1, n
+		}
+	}
+	for i := range runes {
+		expand(i, i)   // odd length, centered on runes[i]
+		expand(i, i+1) // even length, centered between runes[i] and runes[i+1]
+	}
+	return string(runes[start : start+length])
+}
+
 func main() {
 
 	fmt.Println(isPalindrome("racecar"))	
 
+	for _, s := range []string{"", "a", "babad", "cbbd", "abc", "forgeeksskeegfor", "été", "xàbbày"} {
+		fmt.Printf("LongestPalindrome(%q) = %q\n", s, LongestPalindrome(s))
+	}
+
 
 }
\ No newline at end of file
@@ -0,0 +1,25 @@
+package main
+
+import "testing"
+
+func TestLongestPalindrome(t *testing.T) {
+	tests := []struct {
+		s    string
+		want string
+	}{
+		{"", ""},
+		{"a", "a"},
+		{"babad", "bab"}, // "aba" ties; the first one wins
+		{"cbbd", "bb"},
+		{"abba", "abba"},
+		{"abc", "a"},
+		{"forgeeksskeegfor", "geeksskeeg"},
+		{"été", "été"},
+		{"xàbbày", "àbbà"},
+	}
+	for _, tt := range tests {
+		if got := LongestPalindrome(tt.s); got != tt.want {
+			t.Errorf("LongestPalindrome(%q) = %q, want %q", tt.s, got, tt.want)
+		}
+	}
+}
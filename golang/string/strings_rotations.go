@@ -1,6 +1,9 @@
 package main
 
-import "fmt"
+import (
+	"fmt"
+	"strings"
+)
 
 
 // StringsAreRotaions checks if s2 is a rotation of s1.
@@ -13,22 +16,56 @@ func StringsAreRotaions(s1, s2 string) bool {
 		return true
 	}
 	// If s2 is a substring of s1+s1, then s2 is a rotation of s1.
-	return isSubstring(s1+s1, s2)
+	return strings.Contains(s1+s1, s2)
 }
 
-// isSubstring checks if substr is a substring of str.
-func isSubstring(str, substr string) bool {
-	n, m := len(str), len(substr)
-	if m == 0 {
+// StringsAreRotationsRunes checks if s2 is a rotation of s1, shifting whole
+// characters (runes) instead of bytes so a multi-byte character such as "é"
+// or "🙂" is never split in the middle.
+func StringsAreRotationsRunes(s1, s2 string) bool {
+	r1, r2 := []rune(s1), []rune(s2)
+	if len(r1) != len(r2) {
+		return false
+	}
+	n := len(r1)
+	if n == 0 {
 		return true
 	}
-	for i := 0; i <= n-m; i++ {
-		if str[i:i+m] == substr {
+	for shift := 0; shift < n; shift++ {
+		match := true
+		for i := 0; i < n; i++ {
+			if r1[(i+shift)%n] != r2[i] {
+				match = false
+				break
+			}
+		}
+		if match {
 			return true
 		}
 	}
 	return false
 }
+
+// RotationShiftCount returns how many positions s1 must be rotated left to
+// become s2, counting characters (runes) rather than bytes. The bool is false
+// when s2 is not a rotation of s1. Equal strings, including two empty strings,
+// need 0 shifts.
+func RotationShiftCount(s1, s2 string) (int, bool) {
+	r1, r2 := []rune(s1), []rune(s2)
+	if len(r1) != len(r2) {
+		return 0, false
+	}
+	if s1 == s2 {
+		return 0, true
+	}
+	n := len(r1)
+	for shift := 1; shift < n; shift++ {
+		if string(r1[shift:])+string(r1[:shift]) == s2 {
+			return shift, true
+		}
+	}
+	return 0, false
+}
 // BestRotationType determines whether left or right rotation is a better match to convert s1 to s2.
 // It returns "left", "right", or "none" depending on which rotation (if any) can transform s1 into s2
 // with the minimal number of shifts. If both are possible with the same number of shifts, "left" is preferred.
@@ -67,5 +104,14 @@ func BestRotationType(s1, s2 string) string {
 
 func main() {
 	fmt.Println(StringsAreRotaions("abcd", "cdab"))
+	fmt.Println(RotationShiftCount("abcd", "cdab"))
+	fmt.Println(RotationShiftCount("héllo", "llohé"))
+	fmt.Println(StringsAreRotationsRunes("héllo", "lohél"))
+	fmt.Println(StringsAreRotationsRunes("go🙂", "🙂go"))
+	// Shifting "héllo" by two bytes splits "é" and leaves invalid UTF-8
+	// behind: the byte version accepts it, the rune version does not
+	split := "\xa9llo" + "h\xc3"
+	fmt.Println(StringsAreRotaions("héllo", split))
+	fmt.Println(StringsAreRotationsRunes("héllo", split))
 	
 }
@@ -0,0 +1,102 @@
+package main
+
+import "testing"
+
+func TestRotationShiftCount(t *testing.T) {
+	tests := []struct {
+		name   string
+		s1, s2 string
+		shift  int
+		ok     bool
+	}{
+		{"empty strings", "", "", 0, true},
+		{"equal strings", "abcd", "abcd", 0, true},
+		{"left by two", "abcd", "cdab", 2, true},
+		{"left by three", "abcd", "dabc", 3, true},
+		{"not a rotation", "abcd", "acbd", 0, false},
+		{"different lengths", "abc", "abcd", 0, false},
+		// Shifts count runes: "é" is two bytes but a single position
+		{"accented", "h\u00e9llo", "lloh\u00e9", 2, true},
+		{"accent moves to front", "h\u00e9llo", "\u00e9lloh", 1, true},
+		{"emoji", "go\U0001F642", "\U0001F642go", 2, true},
+		{"composed vs decomposed", "\u00e9", "e\u0301", 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			shift, ok := RotationShiftCount(tt.s1, tt.s2)
+			if shift != tt.shift || ok != tt.ok {
+				t.Errorf("RotationShiftCount(%q, %q) = %d, %t, want %d, %t", tt.s1, tt.s2, shift, ok, tt.shift, tt.ok)
+			}
+		})
+	}
+}
+
+func TestStringsAreRotaions(t *testing.T) {
+	tests := []struct {
+		s1, s2 string
+		want   bool
+	}{
+		{"", "", true},
+		{"abcd", "cdab", true},
+		{"abcd", "abcd", true},
+		{"abcd", "acbd", false},
+		{"abc", "abcd", false},
+	}
+	for _, tt := range tests {
+		if got := StringsAreRotaions(tt.s1, tt.s2); got != tt.want {
+			t.Errorf("StringsAreRotaions(%q, %q) = %t, want %t", tt.s1, tt.s2, got, tt.want)
+		}
+	}
+}
+
+func TestBestRotationType(t *testing.T) {
+	tests := []struct {
+		s1, s2 string
+		want   string
+	}{
+		{"abcd", "abcd", "left"},
+		{"abcde", "bcdea", "left"},
+		{"abcde", "eabcd", "right"},
+		{"abcd", "cdab", "left"},
+		{"abcd", "acbd", "none"},
+		{"abc", "ab", "none"},
+	}
+	for _, tt := range tests {
+		if got := BestRotationType(tt.s1, tt.s2); got != tt.want {
+			t.Errorf("BestRotationType(%q, %q) = %q, want %q", tt.s1, tt.s2, got, tt.want)
+		}
+	}
+}
+
+func TestStringsAreRotationsRunes(t *testing.T) {
+	// Rotating "héllo" left by two bytes splits "é" in half
+	const hello = "héllo"
+	splitRune := hello[2:] + hello[:2]
+
+	tests := []struct {
+		name        string
+		s1, s2      string
+		byteVersion bool
+		runeVersion bool
+	}{
+		{"empty", "", "", true, true},
+		{"ascii", "abcd", "cdab", true, true},
+		{"accented", hello, "llohé", true, true},
+		{"emoji", "go\U0001F642", "\U0001F642go", true, true},
+		{"emoji between letters", "a\U0001F642b", "ba\U0001F642", true, true},
+		{"not a rotation", hello, "hélol", false, false},
+		// The byte version accepts a "rotation" that is not valid UTF-8
+		{"split accent", hello, splitRune, true, false},
+		{"split emoji", "go\U0001F642", ("go\U0001F642")[3:] + ("go\U0001F642")[:3], true, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := StringsAreRotaions(tt.s1, tt.s2); got != tt.byteVersion {
+				t.Errorf("StringsAreRotaions(%q, %q) = %t, want %t", tt.s1, tt.s2, got, tt.byteVersion)
+			}
+			if got := StringsAreRotationsRunes(tt.s1, tt.s2); got != tt.runeVersion {
+				t.Errorf("StringsAreRotationsRunes(%q, %q) = %t, want %t", tt.s1, tt.s2, got, tt.runeVersion)
+			}
+		})
+	}
+}
@@ -1,32 +1,80 @@
 package main
 
-import "fmt"
+import (
+	"fmt"
+	"math"
+)
 
 func main() {
 	var a int8 = 127 // Minimum value for int8
 	a++
 	fmt.Println(a) // Output: 127 (wraps around to the maximum value)
 
+	fmt.Println(AddInt8Checked(127, 1))   // -128 true
+	fmt.Println(SubInt8Checked(-128, 1))  // 127 true
+	fmt.Println(MulInt8Checked(-128, -1)) // -128 true
+	fmt.Println(AddInt8Checked(100, 27))  // 127 false
+
+	fmt.Println(TypeName(a), TypeName(uint16(1)), TypeName(float32(1.5)), TypeName([]int{})) // int8 uint16 float32 unknown
+}
+
+// The checked helpers compute in int16, which can hold every result of an
+// int8 addition, subtraction or multiplication, and then compare against the
+// int8 range. The returned value is the wrapped int8 result Go would give
+// with plain arithmetic; the bool reports whether it overflowed.
+
+// AddInt8Checked returns a+b and whether the sum overflowed int8
+func AddInt8Checked(a, b int8) (int8, bool) {
+	return checkInt8(int16(a) + int16(b))
+}
+
+// SubInt8Checked returns a-b and whether the difference overflowed int8
+func SubInt8Checked(a, b int8) (int8, bool) {
+	return checkInt8(int16(a) - int16(b))
+}
+
+// MulInt8Checked returns a*b and whether the product overflowed int8
+func MulInt8Checked(a, b int8) (int8, bool) {
+	return checkInt8(int16(a) * int16(b))
+}
+
+func checkInt8(wide int16) (int8, bool) {
+	return int8(wide), wide < math.MinInt8 || wide > math.MaxInt8
 }
 
-func checkType(i interface{}) {
-	switch v := i.(type) {
+// TypeName returns the name of the basic type stored in i, or "unknown"
+// for any type the switch doesn't list. Note that byte and rune are
+// aliases, so they report as "uint8" and "int32".
+func TypeName(i interface{}) string {
+	switch i.(type) {
 	case int:
-		fmt.Println("Type is int:", v)
+		return "int"
 	case int8:
-		fmt.Println("Type is int8:", v)
+		return "int8"
 	case int16:
-		fmt.Println("Type is int16:", v)
+		return "int16"
 	case int32:
-		fmt.Println("Type is int32:", v)
+		return "int32"
 	case int64:
-		fmt.Println("Type is int64:", v)
+		return "int64"
+	case uint:
+		return "uint"
+	case uint8:
+		return "uint8"
+	case uint16:
+		return "uint16"
+	case uint32:
+		return "uint32"
+	case uint64:
+		return "uint64"
 	case string:
-		fmt.Println("Type is string:", v)
+		return "string"
+	case float32:
+		return "float32"
 	case float64:
-		fmt.Println("Type is float64:", v)
+		return "float64"
 	default:
-		fmt.Println("Unknown type")
+		return "unknown"
 	}
 }
 
@@ -0,0 +1,73 @@
+package main
+
+import "testing"
+
+func TestInt8CheckedArithmetic(t *testing.T) {
+	tests := []struct {
+		name     string
+		op       func(a, b int8) (int8, bool)
+		a, b     int8
+		want     int8
+		overflow bool
+	}{
+		{"add", AddInt8Checked, 1, 2, 3, false},
+		{"add to max", AddInt8Checked, 100, 27, 127, false},
+		{"add 127+1", AddInt8Checked, 127, 1, -128, true},
+		{"add -128+-1", AddInt8Checked, -128, -1, 127, true},
+		{"add to min", AddInt8Checked, -100, -28, -128, false},
+		{"sub", SubInt8Checked, 5, 7, -2, false},
+		{"sub -128-1", SubInt8Checked, -128, 1, 127, true},
+		{"sub 127-(-1)", SubInt8Checked, 127, -1, -128, true},
+		{"sub 0-(-128)", SubInt8Checked, 0, -128, -128, true},
+		{"sub -1-(-128)", SubInt8Checked, -1, -128, 127, false},
+		{"mul", MulInt8Checked, -8, 15, -120, false},
+		{"mul -128*-1", MulInt8Checked, -128, -1, -128, true},
+		{"mul -128*1", MulInt8Checked, -128, 1, -128, false},
+		{"mul 16*8", MulInt8Checked, 16, 8, -128, true},
+		{"mul -16*8", MulInt8Checked, -16, 8, -128, false},
+		{"mul 127*127", MulInt8Checked, 127, 127, 1, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, overflow := tt.op(tt.a, tt.b)
+			if got != tt.want || overflow != tt.overflow {
+				t.Errorf("%s(%d, %d) = %d, %t, want %d, %t", tt.name, tt.a, tt.b, got, overflow, tt.want, tt.overflow)
+			}
+		})
+	}
+}
+
+func TestTypeName(t *testing.T) {
+	tests := []struct {
+		v    interface{}
+		want string
+	}{
+		{int(1), "int"},
+		{int8(1), "int8"},
+		{int16(1), "int16"},
+		{int32(1), "int32"},
+		{int64(1), "int64"},
+		{uint(1), "uint"},
+		{uint8(1), "uint8"},
+		{uint16(1), "uint16"},
+		{uint32(1), "uint32"},
+		{uint64(1), "uint64"},
+		{"s", "string"},
+		{float32(1.5), "float32"},
+		{1.5, "float64"},
+		// byte and rune are aliases, not separate types
+		{byte('a'), "uint8"},
+		{'a', "int32"},
+		// Anything the switch doesn't list
+		{[]int{}, "unknown"},
+		{true, "unknown"},
+		{uintptr(1), "unknown"},
+		{complex(1, 2), "unknown"},
+		{nil, "unknown"},
+	}
+	for _, tt := range tests {
+		if got := TypeName(tt.v); got != tt.want {
+			t.Errorf("TypeName(%#v) = %q, want %q", tt.v, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,100 @@
+package main
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Memoization caches a function's results by argument, which turns many
+// top-down dynamic-programming solutions from exponential into polynomial
+// time. Results live in a sync.Map so the wrapped function can be shared
+// between goroutines.
+
+// memoEntry holds one cached result. The sync.Once makes concurrent callers
+// asking for the same key wait for a single computation instead of each
+// running fn themselves.
+type memoEntry[V any] struct {
+	once    sync.Once
+	value   V
+	expires time.Time
+	done    bool // fn returned; false after Do means fn panicked
+}
+
+// load returns the entry for key once fn has computed it. If fn panics, the
+// entry is removed before the panic carries on, so a later call runs fn again
+// instead of getting a zero value forever. Callers that were waiting on the
+// panicking call retry with a fresh entry.
+func load[K comparable, V any](cache *sync.Map, key K, fn func(K) V, ttl time.Duration) *memoEntry[V] {
+	for {
+		actual, _ := cache.LoadOrStore(key, &memoEntry[V]{})
+		entry := actual.(*memoEntry[V])
+		entry.once.Do(func() {
+			defer func() {
+				if !entry.done {
+					cache.CompareAndDelete(key, entry)
+				}
+			}()
+			entry.value = fn(key)
+			entry.expires = time.Now().Add(ttl)
+			entry.done = true
+		})
+		if entry.done {
+			return entry
+		}
+	}
+}
+
+// Memoize returns a function that calls fn at most once per distinct key and
+// serves every later call from the cache
+func Memoize[K comparable, V any](fn func(K) V) func(K) V {
+	var cache sync.Map // K -> *memoEntry[V]
+	return func(key K) V {
+		return load(&cache, key, fn, 0).value
+	}
+}
+
+// MemoizeTTL is like Memoize but forgets a result ttl after it was computed,
+// so the next call for that key runs fn again. A ttl of zero or less caches
+// nothing.
+func MemoizeTTL[K comparable, V any](fn func(K) V, ttl time.Duration) func(K) V {
+	if ttl <= 0 {
+		return fn
+	}
+	var cache sync.Map // K -> *memoEntry[V]
+	return func(key K) V {
+		for {
+			entry := load(&cache, key, fn, ttl)
+			if time.Now().Before(entry.expires) {
+				return entry.value
+			}
+			// Only the caller that removes the stale entry matters; everyone
+			// then retries and shares the fresh entry that replaces it
+			cache.CompareAndDelete(key, entry)
+		}
+	}
+}
+
+func main() {
+	// Fibonacci is the classic case: the naive recursion recomputes the same
+	// subproblems, memoizing it makes each n computed once
+	var fibCalls int
+	var fib func(int) int
+	fib = Memoize(func(n int) int {
+		fibCalls++
+		if n < 2 {
+			return n
+		}
+		return fib(n-1) + fib(n-2)
+	})
+	fmt.Println("fib(80) =", fib(80), "computed with", fibCalls, "calls") // 81 calls
+
+	var ttlCalls int
+	version := MemoizeTTL(func(key string) int {
+		ttlCalls++
+		return ttlCalls
+	}, 50*time.Millisecond)
+	fmt.Println("First call", version("k"), "cached call", version("k")) // 1 1
+	time.Sleep(60 * time.Millisecond)
+	fmt.Println("After TTL expired", version("k")) // 2
+}
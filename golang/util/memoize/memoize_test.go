@@ -0,0 +1,128 @@
+package main
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestMemoizeFibonacci(t *testing.T) {
+	var calls int
+	var fib func(int) int
+	fib = Memoize(func(n int) int {
+		calls++
+		if n < 2 {
+			return n
+		}
+		return fib(n-1) + fib(n-2)
+	})
+	if got := fib(80); got != 23416728348467685 {
+		t.Errorf("fib(80) = %d, want 23416728348467685", got)
+	}
+	if calls != 81 {
+		t.Errorf("fn ran %d times, want 81: once per n in 0..80", calls)
+	}
+	fib(80)
+	if calls != 81 {
+		t.Errorf("fn ran %d times after a repeat call, want 81", calls)
+	}
+}
+
+func TestMemoizeCallsOncePerKeyConcurrently(t *testing.T) {
+	const keys = 5
+	var calls [keys]atomic.Int64
+	square := Memoize(func(n int) int {
+		calls[n].Add(1)
+		time.Sleep(10 * time.Millisecond) // keep callers overlapping
+		return n * n
+	})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 100; i++ {
+		wg.Add(1)
+		go func(n int) {
+			defer wg.Done()
+			if got := square(n); got != n*n {
+				t.Errorf("square(%d) = %d, want %d", n, got, n*n)
+			}
+		}(i % keys)
+	}
+	wg.Wait()
+	for n := range calls {
+		if got := calls[n].Load(); got != 1 {
+			t.Errorf("fn ran %d times for key %d, want 1", got, n)
+		}
+	}
+}
+
+// panics reports whether call panicked
+func panics(call func()) (panicked bool) {
+	defer func() { panicked = recover() != nil }()
+	call()
+	return false
+}
+
+func TestMemoizeRetriesAfterPanic(t *testing.T) {
+	var calls int
+	flaky := Memoize(func(n int) int {
+		calls++
+		if calls == 1 {
+			panic("transient failure")
+		}
+		return n * 10
+	})
+
+	if !panics(func() { flaky(1) }) {
+		t.Fatal("first call didn't panic")
+	}
+	// The panicking call must not have cached a zero value
+	if got := flaky(1); got != 10 {
+		t.Errorf("call after a panic = %d, want 10", got)
+	}
+	if got := flaky(1); got != 10 || calls != 2 {
+		t.Errorf("cached call = %d after %d runs of fn, want 10 after 2", got, calls)
+	}
+}
+
+func TestMemoizeTTL(t *testing.T) {
+	var calls atomic.Int64
+	version := MemoizeTTL(func(string) int64 { return calls.Add(1) }, 50*time.Millisecond)
+
+	if first, cached := version("k"), version("k"); first != 1 || cached != 1 {
+		t.Errorf("first and cached calls = %d, %d, want 1, 1", first, cached)
+	}
+	if got := version("other"); got != 2 {
+		t.Errorf("call for another key = %d, want 2", got)
+	}
+	time.Sleep(60 * time.Millisecond)
+	if got := version("k"); got != 3 {
+		t.Errorf("call after the TTL = %d, want 3", got)
+	}
+}
+
+func TestMemoizeTTLRetriesAfterPanic(t *testing.T) {
+	var calls int
+	flaky := MemoizeTTL(func(n int) int {
+		calls++
+		if calls == 1 {
+			panic("transient failure")
+		}
+		return n * 10
+	}, time.Minute)
+
+	if !panics(func() { flaky(1) }) {
+		t.Fatal("first call didn't panic")
+	}
+	if got := flaky(1); got != 10 {
+		t.Errorf("call after a panic = %d, want 10", got)
+	}
+}
+
+func TestMemoizeTTLNonPositiveCachesNothing(t *testing.T) {
+	var calls int
+	count := MemoizeTTL(func(int) int { calls++; return calls }, 0)
+	if first, second := count(1), count(1); first != 1 || second != 2 {
+		t.Errorf("calls with ttl 0 = %d, %d, want 1, 2", first, second)
+	}
+}
@@ -1,7 +1,9 @@
 package main
 
 import (
+	"encoding/json"
 	"fmt"
+	"regexp"
 	"strings"
 )
 
@@ -19,6 +21,8 @@ type DocumentVisitor interface {
 	VisitImage(i *Image)
 	VisitTable(t *Table)
 	VisitCodeBlock(c *CodeBlock)
+	VisitOrderedList(l *OrderedList)
+	VisitUnorderedList(l *UnorderedList)
 }
 
 // DocumentElement is the element interface
@@ -70,6 +74,16 @@ func (t *Table) Accept(v DocumentVisitor) {
 	v.VisitTable(t)
 }
 
+// Validate checks that every row has exactly one cell per header
+func (t *Table) Validate() error {
+	for i, row := range t.Rows {
+		if len(row) != len(t.Headers) {
+			return fmt.Errorf("table row %d has %d cells, want %d (one per header)", i, len(row), len(t.Headers))
+		}
+	}
+	return nil
+}
+
 // CodeBlock represents a code snippet
 type CodeBlock struct {
 	Language string
@@ -80,6 +94,41 @@ func (c *CodeBlock) Accept(v DocumentVisitor) {
 	v.VisitCodeBlock(c)
 }
 
+// OrderedList represents a numbered list
+type OrderedList struct {
+	Items []string
+}
+
+func (l *OrderedList) Accept(v DocumentVisitor) {
+	v.VisitOrderedList(l)
+}
+
+// UnorderedList represents a bulleted list
+type UnorderedList struct {
+	Items []string
+}
+
+func (l *UnorderedList) Accept(v DocumentVisitor) {
+	v.VisitUnorderedList(l)
+}
+
+// ============================================================================
+// BASE VISITOR - No-op Defaults
+// ============================================================================
+
+// BaseDocumentVisitor implements every DocumentVisitor method as a no-op.
+// Embed it in a visitor that only cares about some element types so that
+// adding new elements to the interface doesn't break it.
+type BaseDocumentVisitor struct{}
+
+func (BaseDocumentVisitor) VisitParagraph(p *Paragraph)         {}
+func (BaseDocumentVisitor) VisitHeading(h *Heading)             {}
+func (BaseDocumentVisitor) VisitImage(i *Image)                 {}
+func (BaseDocumentVisitor) VisitTable(t *Table)                 {}
+func (BaseDocumentVisitor) VisitCodeBlock(c *CodeBlock)         {}
+func (BaseDocumentVisitor) VisitOrderedList(l *OrderedList)     {}
+func (BaseDocumentVisitor) VisitUnorderedList(l *UnorderedList) {}
+
 // ============================================================================
 // CONCRETE VISITORS - Different Export Formats
 // ============================================================================
@@ -128,6 +177,22 @@ func (h *HTMLExporter) VisitCodeBlock(c *CodeBlock) {
 	h.output.WriteString(fmt.Sprintf("<pre><code class=\"language-%s\">\n%s\n</code></pre>\n", c.Language, c.Code))
 }
 
+func (h *HTMLExporter) VisitOrderedList(l *OrderedList) {
+	h.writeList("ol", l.Items)
+}
+
+func (h *HTMLExporter) VisitUnorderedList(l *UnorderedList) {
+	h.writeList("ul", l.Items)
+}
+
+func (h *HTMLExporter) writeList(tag string, items []string) {
+	h.output.WriteString(fmt.Sprintf("<%s>\n", tag))
+	for _, item := range items {
+		h.output.WriteString(fmt.Sprintf("  <li>%s</li>\n", item))
+	}
+	h.output.WriteString(fmt.Sprintf("</%s>\n", tag))
+}
+
 func (h *HTMLExporter) GetOutput() string {
 	return h.output.String()
 }
@@ -155,35 +220,70 @@ func (m *MarkdownExporter) VisitImage(i *Image) {
 }
 
 func (m *MarkdownExporter) VisitTable(t *Table) {
-	// Headers
-	m.output.WriteString("| ")
-	for _, header := range t.Headers {
-		m.output.WriteString(fmt.Sprintf("%s | ", header))
+	// Use the widest row so jagged tables still line up with the separator
+	columns := len(t.Headers)
+	for _, row := range t.Rows {
+		if len(row) > columns {
+			columns = len(row)
+		}
 	}
-	m.output.WriteString("\n")
+
+	// Headers
+	m.writeTableRow(t.Headers, columns)
 
 	// Separator
 	m.output.WriteString("|")
-	for range t.Headers {
+	for i := 0; i < columns; i++ {
 		m.output.WriteString("---|")
 	}
 	m.output.WriteString("\n")
 
 	// Rows
 	for _, row := range t.Rows {
-		m.output.WriteString("| ")
-		for _, cell := range row {
-			m.output.WriteString(fmt.Sprintf("%s | ", cell))
+		m.writeTableRow(row, columns)
+	}
+	m.output.WriteString("\n")
+}
+
+// writeTableRow writes one table row, padding missing cells with blanks
+func (m *MarkdownExporter) writeTableRow(cells []string, columns int) {
+	m.output.WriteString("| ")
+	for i := 0; i < columns; i++ {
+		cell := ""
+		if i < len(cells) {
+			cell = escapeMarkdownCell(cells[i])
 		}
-		m.output.WriteString("\n")
+		m.output.WriteString(fmt.Sprintf("%s | ", cell))
 	}
 	m.output.WriteString("\n")
 }
 
+var markdownCellReplacer = strings.NewReplacer("|", `\|`, "\r\n", " ", "\n", " ")
+
+// escapeMarkdownCell escapes pipes and collapses newlines so the cell
+// text cannot break out of its table column
+func escapeMarkdownCell(cell string) string {
+	return markdownCellReplacer.Replace(cell)
+}
+
 func (m *MarkdownExporter) VisitCodeBlock(c *CodeBlock) {
 	m.output.WriteString(fmt.Sprintf("```%s\n%s\n```\n\n", c.Language, c.Code))
 }
 
+func (m *MarkdownExporter) VisitOrderedList(l *OrderedList) {
+	for i, item := range l.Items {
+		m.output.WriteString(fmt.Sprintf("%d. %s\n", i+1, item))
+	}
+	m.output.WriteString("\n")
+}
+
+func (m *MarkdownExporter) VisitUnorderedList(l *UnorderedList) {
+	for _, item := range l.Items {
+		m.output.WriteString(fmt.Sprintf("- %s\n", item))
+	}
+	m.output.WriteString("\n")
+}
+
 func (m *MarkdownExporter) GetOutput() string {
 	return m.output.String()
 }
@@ -254,22 +354,138 @@ func (p *PlainTextExporter) VisitCodeBlock(c *CodeBlock) {
 	p.output.WriteString("\n----------------------------------------\n\n")
 }
 
+func (p *PlainTextExporter) VisitOrderedList(l *OrderedList) {
+	for i, item := range l.Items {
+		p.output.WriteString(fmt.Sprintf("  %d. %s\n", i+1, item))
+	}
+	p.output.WriteString("\n")
+}
+
+func (p *PlainTextExporter) VisitUnorderedList(l *UnorderedList) {
+	for _, item := range l.Items {
+		p.output.WriteString(fmt.Sprintf("  * %s\n", item))
+	}
+	p.output.WriteString("\n")
+}
+
 func (p *PlainTextExporter) GetOutput() string {
 	return p.output.String()
 }
 
+// jsonElement is the wire format shared by JSONExporter and ParseDocumentJSON.
+// The Type field selects which concrete element the object describes.
+type jsonElement struct {
+	Type     string     `json:"type"`
+	Level    int        `json:"level,omitempty"`
+	Text     string     `json:"text,omitempty"`
+	URL      string     `json:"url,omitempty"`
+	AltText  string     `json:"alt,omitempty"`
+	Caption  string     `json:"caption,omitempty"`
+	Headers  []string   `json:"headers,omitempty"`
+	Rows     [][]string `json:"rows,omitempty"`
+	Language string     `json:"language,omitempty"`
+	Code     string     `json:"code,omitempty"`
+	Items    []string   `json:"items,omitempty"`
+}
+
+// JSONExporter exports document to a JSON array of typed element objects
+type JSONExporter struct {
+	elements []jsonElement
+}
+
+func (j *JSONExporter) VisitParagraph(p *Paragraph) {
+	j.elements = append(j.elements, jsonElement{Type: "paragraph", Text: p.Text})
+}
+
+func (j *JSONExporter) VisitHeading(h *Heading) {
+	j.elements = append(j.elements, jsonElement{Type: "heading", Level: h.Level, Text: h.Text})
+}
+
+func (j *JSONExporter) VisitImage(i *Image) {
+	j.elements = append(j.elements, jsonElement{Type: "image", URL: i.URL, AltText: i.AltText, Caption: i.Caption})
+}
+
+func (j *JSONExporter) VisitTable(t *Table) {
+	j.elements = append(j.elements, jsonElement{Type: "table", Headers: t.Headers, Rows: t.Rows})
+}
+
+func (j *JSONExporter) VisitCodeBlock(c *CodeBlock) {
+	j.elements = append(j.elements, jsonElement{Type: "code", Language: c.Language, Code: c.Code})
+}
+
+func (j *JSONExporter) VisitOrderedList(l *OrderedList) {
+	j.elements = append(j.elements, jsonElement{Type: "ordered_list", Items: l.Items})
+}
+
+func (j *JSONExporter) VisitUnorderedList(l *UnorderedList) {
+	j.elements = append(j.elements, jsonElement{Type: "unordered_list", Items: l.Items})
+}
+
+func (j *JSONExporter) GetOutput() string {
+	elements := j.elements
+	if elements == nil {
+		elements = []jsonElement{}
+	}
+	// Marshalling only strings, ints and slices of them cannot fail
+	data, _ := json.MarshalIndent(elements, "", "  ")
+	return string(data)
+}
+
+// FilterVisitor collects the elements that match a predicate into a new
+// Document. The predicate gets the element interface, so callers type-assert
+// to select by kind, e.g. keep only headings or only code blocks.
+type FilterVisitor struct {
+	predicate func(DocumentElement) bool
+	result    *Document
+}
+
+// NewFilterVisitor returns a FilterVisitor that keeps elements for which
+// predicate returns true
+func NewFilterVisitor(predicate func(DocumentElement) bool) *FilterVisitor {
+	return &FilterVisitor{predicate: predicate, result: &Document{}}
+}
+
+func (f *FilterVisitor) keep(element DocumentElement) {
+	if f.predicate(element) {
+		f.result.AddElement(element)
+	}
+}
+
+func (f *FilterVisitor) VisitParagraph(p *Paragraph)         { f.keep(p) }
+func (f *FilterVisitor) VisitHeading(h *Heading)             { f.keep(h) }
+func (f *FilterVisitor) VisitImage(i *Image)                 { f.keep(i) }
+func (f *FilterVisitor) VisitTable(t *Table)                 { f.keep(t) }
+func (f *FilterVisitor) VisitCodeBlock(c *CodeBlock)         { f.keep(c) }
+func (f *FilterVisitor) VisitOrderedList(l *OrderedList)     { f.keep(l) }
+func (f *FilterVisitor) VisitUnorderedList(l *UnorderedList) { f.keep(l) }
+
+// Result returns the document holding the matching elements, in order
+func (f *FilterVisitor) Result() *Document {
+	return f.result
+}
+
 // ============================================================================
 // DOCUMENT - Client Code
 // ============================================================================
 
 // Document holds a collection of document elements
 type Document struct {
-	Title    string
+	Title string
+	// Strict makes AddElement reject elements that fail their Validate method
+	Strict   bool
 	elements []DocumentElement
 }
 
-func (d *Document) AddElement(element DocumentElement) {
+// AddElement appends element to the document. When Strict is set, an element
+// with a Validate method is checked first and not added if it is invalid.
+func (d *Document) AddElement(element DocumentElement) error {
+	if v, ok := element.(interface{ Validate() error }); ok && d.Strict {
+		if err := v.Validate(); err != nil {
+			return err
+		}
+	}
 	d.elements = append(d.elements, element)
+	return nil
 }
 
 func (d *Document) Export(visitor DocumentVisitor) {
@@ -278,6 +494,176 @@ func (d *Document) Export(visitor DocumentVisitor) {
 	}
 }
 
+// ParseDocumentJSON rebuilds a Document from the output of JSONExporter,
+// restoring the concrete element type of every entry.
+func ParseDocumentJSON(data []byte) (*Document, error) {
+	var elements []jsonElement
+	if err := json.Unmarshal(data, &elements); err != nil {
+		return nil, fmt.Errorf("parse document json: %w", err)
+	}
+
+	doc := &Document{}
+	for i, e := range elements {
+		switch e.Type {
+		case "paragraph":
+			doc.AddElement(&Paragraph{Text: e.Text})
+		case "heading":
+			doc.AddElement(&Heading{Text: e.Text, Level: e.Level})
+		case "image":
+			doc.AddElement(&Image{URL: e.URL, AltText: e.AltText, Caption: e.Caption})
+		case "table":
+			doc.AddElement(&Table{Headers: e.Headers, Rows: e.Rows})
+		case "code":
+			doc.AddElement(&CodeBlock{Language: e.Language, Code: e.Code})
+		case "ordered_list":
+			doc.AddElement(&OrderedList{Items: e.Items})
+		case "unordered_list":
+			doc.AddElement(&UnorderedList{Items: e.Items})
+		default:
+			return nil, fmt.Errorf("parse document json: element %d has unknown type %q", i, e.Type)
+		}
+	}
+	return doc, nil
+}
+
+var orderedItemPattern = regexp.MustCompile(`^\d+\. `)
+
+// ParseMarkdown rebuilds a Document from the output of MarkdownExporter. It
+// understands only that subset of Markdown: # headings, paragraphs, fenced
+// code blocks, pipe tables, images with an optional *caption* line, and
+// ordered and unordered lists. Blocks are separated by blank lines.
+func ParseMarkdown(src string) (*Document, error) {
+	lines := strings.Split(strings.ReplaceAll(src, "\r\n", "\n"), "\n")
+	doc := &Document{}
+	for i := 0; i < len(lines); {
+		line := lines[i]
+		switch {
+		case line == "":
+			i++
+
+		case strings.HasPrefix(line, "```"):
+			end := i + 1
+			for end < len(lines) && lines[end] != "```" {
+				end++
+			}
+			if end == len(lines) {
+				return nil, fmt.Errorf("parse markdown line %d: unterminated code block", i+1)
+			}
+			doc.AddElement(&CodeBlock{
+				Language: strings.TrimPrefix(line, "```"),
+				Code:     strings.Join(lines[i+1:end], "\n"),
+			})
+			i = end + 1
+
+		case headingLevel(line) > 0:
+			level := headingLevel(line)
+			doc.AddElement(&Heading{Text: line[level+1:], Level: level})
+			i++
+
+		case strings.HasPrefix(line, "|"):
+			if i+1 >= len(lines) || !isTableSeparator(lines[i+1]) {
+				return nil, fmt.Errorf("parse markdown line %d: table header without separator row", i+1)
+			}
+			table := &Table{Headers: parseTableRow(line)}
+			i += 2
+			for ; i < len(lines) && strings.HasPrefix(lines[i], "|"); i++ {
+				table.Rows = append(table.Rows, parseTableRow(lines[i]))
+			}
+			doc.AddElement(table)
+
+		case strings.HasPrefix(line, "!["):
+			image, ok := parseImage(line)
+			if !ok {
+				return nil, fmt.Errorf("parse markdown line %d: malformed image %q", i+1, line)
+			}
+			i++
+			if i < len(lines) && len(lines[i]) >= 2 && strings.HasPrefix(lines[i], "*") && strings.HasSuffix(lines[i], "*") {
+				image.Caption = lines[i][1 : len(lines[i])-1]
+				i++
+			}
+			doc.AddElement(image)
+
+		case strings.HasPrefix(line, "- "):
+			list := &UnorderedList{}
+			for ; i < len(lines) && strings.HasPrefix(lines[i], "- "); i++ {
+				list.Items = append(list.Items, lines[i][2:])
+			}
+			doc.AddElement(list)
+
+		case orderedItemPattern.MatchString(line):
+			list := &OrderedList{}
+			for ; i < len(lines) && orderedItemPattern.MatchString(lines[i]); i++ {
+				prefix := orderedItemPattern.FindString(lines[i])
+				list.Items = append(list.Items, lines[i][len(prefix):])
+			}
+			doc.AddElement(list)
+
+		default:
+			// A paragraph runs until the next blank line
+			end := i
+			for end < len(lines) && lines[end] != "" {
+				end++
+			}
+			doc.AddElement(&Paragraph{Text: strings.Join(lines[i:end], "\n")})
+			i = end
+		}
+	}
+	return doc, nil
+}
+
+// headingLevel returns the level of a "# Heading" line, or 0 if it isn't one
+func headingLevel(line string) int {
+	level := 0
+	for level < len(line) && line[level] == '#' {
+		level++
+	}
+	if level == 0 || level > 6 || level == len(line) || line[level] != ' ' {
+		return 0
+	}
+	return level
+}
+
+// isTableSeparator reports whether line is a |---|---| row
+func isTableSeparator(line string) bool {
+	return len(line) > 1 && strings.Trim(line, "|-") == "" && strings.HasPrefix(line, "|---")
+}
+
+// parseTableRow splits a "| a | b | " row into its cells, undoing
+// escapeMarkdownCell's \| escapes
+func parseTableRow(line string) []string {
+	var cells []string
+	var cell strings.Builder
+	for i := 0; i < len(line); i++ {
+		switch {
+		case line[i] == '\\' && i+1 < len(line) && line[i+1] == '|':
+			cell.WriteByte('|')
+			i++
+		case line[i] == '|':
+			cells = append(cells, cell.String())
+			cell.Reset()
+		default:
+			cell.WriteByte(line[i])
+		}
+	}
+	// Drop the empty text before the first pipe, and strip the single space
+	// the exporter puts on each side of a cell
+	cells = cells[1:]
+	for i, c := range cells {
+		c = strings.TrimPrefix(c, " ")
+		cells[i] = strings.TrimSuffix(c, " ")
+	}
+	return cells
+}
+
+// parseImage parses a "![alt](url)" line
+func parseImage(line string) (*Image, bool) {
+	middle := strings.Index(line, "](")
+	if middle < 0 || !strings.HasSuffix(line, ")") {
+		return nil, false
+	}
+	return &Image{AltText: line[2:middle], URL: line[middle+2 : len(line)-1]}, true
+}
+
 // ============================================================================
 // MAIN - Demonstration
 // ============================================================================
@@ -315,6 +701,14 @@ func main() {
 		},
 	})
 
+	doc.AddElement(&UnorderedList{
+		Items: []string{"Elements accept visitors", "Visitors implement operations"},
+	})
+
+	doc.AddElement(&OrderedList{
+		Items: []string{"Define the visitor interface", "Implement Accept on elements", "Write concrete visitors"},
+	})
+
 	doc.AddElement(&Heading{
 		Text:  "Example Code",
 		Level: 2,
@@ -359,8 +753,66 @@ type Element interface {
 	doc.Export(txtExporter)
 	fmt.Println(txtExporter.GetOutput())
 
+	// Export to JSON and parse it back
+	fmt.Println("📋 JSON OUTPUT:")
+	fmt.Println("═══════════════════════════════════════════════════════════")
+	jsonExporter := &JSONExporter{}
+	doc.Export(jsonExporter)
+	fmt.Println(jsonExporter.GetOutput())
+
+	parsed, err := ParseDocumentJSON([]byte(jsonExporter.GetOutput()))
+	if err != nil {
+		fmt.Println("Error:", err)
+		return
+	}
+	fmt.Printf("\n🔁 Parsed the JSON back into %d elements\n\n", len(parsed.elements))
+
+	// Parse the Markdown back as well
+	markdown := &MarkdownExporter{}
+	doc.Export(markdown)
+	parsedMarkdown, err := ParseMarkdown(markdown.GetOutput())
+	if err != nil {
+		fmt.Println("Error:", err)
+		return
+	}
+	fmt.Printf("🔁 Parsed the Markdown back into %d elements\n", len(parsedMarkdown.elements))
+	_, err = ParseMarkdown("```go\nfmt.Println()\n")
+	fmt.Printf("🔁 Unterminated code block: %v\n\n", err)
+
+	// Extract only the headings into a new document
+	fmt.Println("🔎 HEADINGS ONLY:")
+	fmt.Println("═══════════════════════════════════════════════════════════")
+	headingFilter := NewFilterVisitor(func(e DocumentElement) bool {
+		_, ok := e.(*Heading)
+		return ok
+	})
+	doc.Export(headingFilter)
+	outline := headingFilter.Result()
+	outline.Title = doc.Title + " (outline)"
+	outlineExporter := &MarkdownExporter{}
+	outline.Export(outlineExporter)
+	fmt.Println(outlineExporter.GetOutput())
+
+	// Validate tables before they reach an exporter
+	fmt.Println("✅ TABLE VALIDATION:")
+	fmt.Println("═══════════════════════════════════════════════════════════")
+	jagged := &Table{
+		Headers: []string{"Name", "Role"},
+		Rows:    [][]string{{"Ada", "Engineer"}, {"Grace"}},
+	}
+	wellFormed := &Table{
+		Headers: []string{"Name", "Role"},
+		Rows:    [][]string{{"Ada", "Engineer"}, {"Grace", "Admiral"}},
+	}
+	fmt.Println("jagged table:", jagged.Validate())
+	fmt.Println("well-formed table:", wellFormed.Validate())
+	strict := &Document{Title: "Strict", Strict: true}
+	fmt.Println("strict AddElement(jagged):", strict.AddElement(jagged))
+	fmt.Println("strict AddElement(well-formed):", strict.AddElement(wellFormed))
+	fmt.Println()
+
 	fmt.Println("✨ Key Takeaway:")
-	fmt.Println("   We exported the same document to 3 different formats")
+	fmt.Println("   We exported the same document to 4 different formats")
 	fmt.Println("   without modifying any of the document element classes!")
 	fmt.Println("   Each exporter (visitor) encapsulates a different export algorithm. 🚀")
 }
@@ -0,0 +1,288 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+// renderMarkdown exports doc with a fresh MarkdownExporter
+func renderMarkdown(doc *Document) string {
+	exporter := &MarkdownExporter{}
+	doc.Export(exporter)
+	return exporter.GetOutput()
+}
+
+func TestMarkdownTableEscapesPipes(t *testing.T) {
+	doc := &Document{}
+	doc.AddElement(&Table{
+		Headers: []string{"Expression"},
+		Rows:    [][]string{{"a|b"}},
+	})
+
+	lines := strings.Split(renderMarkdown(doc), "\n")
+	if len(lines) < 3 {
+		t.Fatalf("rendered table has %d lines, want at least 3:\n%s", len(lines), strings.Join(lines, "\n"))
+	}
+	row := lines[2]
+	if want := `| a\|b | `; row != want {
+		t.Errorf("row = %q, want %q", row, want)
+	}
+	cells := parseTableRow(row)
+	if len(cells) != 1 || cells[0] != "a|b" {
+		t.Errorf("row parses as %q, want a single cell %q", cells, "a|b")
+	}
+}
+
+func TestMarkdownTableCollapsesNewlines(t *testing.T) {
+	doc := &Document{}
+	doc.AddElement(&Table{
+		Headers: []string{"Note"},
+		Rows:    [][]string{{"first\nsecond\r\nthird"}},
+	})
+
+	lines := strings.Split(renderMarkdown(doc), "\n")
+	if want := "| first second third | "; lines[2] != want {
+		t.Errorf("row = %q, want %q", lines[2], want)
+	}
+}
+
+func TestMarkdownTableSeparatorUsesWidestRow(t *testing.T) {
+	doc := &Document{}
+	doc.AddElement(&Table{
+		Headers: []string{"A"},
+		Rows:    [][]string{{"1", "2", "3"}, {"4"}},
+	})
+
+	lines := strings.Split(renderMarkdown(doc), "\n")
+	want := []string{
+		"| A |  |  | ",
+		"|---|---|---|",
+		"| 1 | 2 | 3 | ",
+		"| 4 |  |  | ",
+	}
+	for i, w := range want {
+		if lines[i] != w {
+			t.Errorf("line %d = %q, want %q", i, lines[i], w)
+		}
+	}
+}
+
+func TestListsRenderInEveryExporter(t *testing.T) {
+	doc := &Document{}
+	doc.AddElement(&OrderedList{Items: []string{"one", "two"}})
+	doc.AddElement(&UnorderedList{Items: []string{"apple", "pear"}})
+
+	html := &HTMLExporter{}
+	plain := &PlainTextExporter{}
+	doc.Export(html)
+	doc.Export(plain)
+
+	tests := []struct {
+		name string
+		got  string
+		want string
+	}{
+		{
+			name: "html",
+			got:  html.GetOutput(),
+			want: "<ol>\n  <li>one</li>\n  <li>two</li>\n</ol>\n<ul>\n  <li>apple</li>\n  <li>pear</li>\n</ul>\n",
+		},
+		{
+			name: "markdown",
+			got:  renderMarkdown(doc),
+			want: "1. one\n2. two\n\n- apple\n- pear\n\n",
+		},
+		{
+			name: "plain text",
+			got:  plain.GetOutput(),
+			want: "  1. one\n  2. two\n\n  * apple\n  * pear\n\n",
+		},
+	}
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("%s output = %q, want %q", tt.name, tt.got, tt.want)
+		}
+	}
+}
+
+// headingCounter only overrides VisitHeading and relies on
+// BaseDocumentVisitor for every other element
+type headingCounter struct {
+	BaseDocumentVisitor
+	count int
+}
+
+func (h *headingCounter) VisitHeading(*Heading) { h.count++ }
+
+func TestBaseDocumentVisitorDefaults(t *testing.T) {
+	doc := &Document{}
+	doc.AddElement(&Heading{Text: "Title", Level: 1})
+	doc.AddElement(&OrderedList{Items: []string{"a"}})
+	doc.AddElement(&UnorderedList{Items: []string{"b"}})
+	doc.AddElement(&Heading{Text: "Section", Level: 2})
+
+	counter := &headingCounter{}
+	doc.Export(counter)
+	if counter.count != 2 {
+		t.Errorf("counted %d headings, want 2", counter.count)
+	}
+}
+
+func TestFilterVisitorKeepsOnlyHeadings(t *testing.T) {
+	title := &Heading{Text: "Title", Level: 1}
+	section := &Heading{Text: "Section", Level: 2}
+	doc := &Document{}
+	doc.AddElement(title)
+	doc.AddElement(&Paragraph{Text: "intro"})
+	doc.AddElement(&CodeBlock{Language: "go", Code: "x := 1"})
+	doc.AddElement(section)
+
+	filter := NewFilterVisitor(func(e DocumentElement) bool {
+		_, ok := e.(*Heading)
+		return ok
+	})
+	doc.Export(filter)
+
+	got := filter.Result().elements
+	if len(got) != 2 || got[0] != title || got[1] != section {
+		t.Errorf("filtered elements = %v, want the two headings in order", got)
+	}
+	if len(doc.elements) != 4 {
+		t.Errorf("source document has %d elements after filtering, want 4", len(doc.elements))
+	}
+}
+
+func TestFilterVisitorNoMatches(t *testing.T) {
+	doc := &Document{}
+	doc.AddElement(&Paragraph{Text: "only text"})
+
+	filter := NewFilterVisitor(func(DocumentElement) bool { return false })
+	doc.Export(filter)
+	if got := filter.Result().elements; len(got) != 0 {
+		t.Errorf("filtered elements = %v, want none", got)
+	}
+}
+
+func TestTableValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		table   *Table
+		wantErr bool
+	}{
+		{
+			name:  "well-formed",
+			table: &Table{Headers: []string{"A", "B"}, Rows: [][]string{{"1", "2"}, {"3", "4"}}},
+		},
+		{
+			name:  "no rows",
+			table: &Table{Headers: []string{"A"}},
+		},
+		{
+			name:    "short row",
+			table:   &Table{Headers: []string{"A", "B"}, Rows: [][]string{{"1", "2"}, {"3"}}},
+			wantErr: true,
+		},
+		{
+			name:    "long row",
+			table:   &Table{Headers: []string{"A"}, Rows: [][]string{{"1", "2"}}},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		if err := tt.table.Validate(); (err != nil) != tt.wantErr {
+			t.Errorf("%s: Validate() = %v, want error: %v", tt.name, err, tt.wantErr)
+		}
+	}
+}
+
+func TestStrictDocumentRejectsJaggedTable(t *testing.T) {
+	jagged := &Table{Headers: []string{"A", "B"}, Rows: [][]string{{"1"}}}
+	wellFormed := &Table{Headers: []string{"A"}, Rows: [][]string{{"1"}}}
+
+	strict := &Document{Strict: true}
+	if err := strict.AddElement(jagged); err == nil {
+		t.Error("strict AddElement(jagged table) = nil, want an error")
+	}
+	if err := strict.AddElement(wellFormed); err != nil {
+		t.Errorf("strict AddElement(well-formed table) = %v, want nil", err)
+	}
+	if len(strict.elements) != 1 || strict.elements[0] != wellFormed {
+		t.Errorf("strict document holds %v, want only the well-formed table", strict.elements)
+	}
+
+	lenient := &Document{}
+	if err := lenient.AddElement(jagged); err != nil {
+		t.Errorf("non-strict AddElement(jagged table) = %v, want nil", err)
+	}
+}
+
+// newRoundTripDocument holds one of every element, plus the awkward cases for
+// Markdown: an escaped pipe in a table cell and a multi-line paragraph
+func newRoundTripDocument() *Document {
+	doc := &Document{Title: "Round Trip"}
+	doc.AddElement(&Heading{Text: "Introduction", Level: 1})
+	doc.AddElement(&Paragraph{Text: "The Visitor pattern separates algorithms from objects."})
+	doc.AddElement(&Heading{Text: "Details", Level: 3})
+	doc.AddElement(&Table{
+		Headers: []string{"Operator", "Meaning"},
+		Rows:    [][]string{{"a | b", "bitwise or"}, {"a &^ b", "and not"}},
+	})
+	doc.AddElement(&UnorderedList{Items: []string{"Elements accept visitors", "Visitors implement operations"}})
+	doc.AddElement(&OrderedList{Items: []string{"Define the interface", "Implement Accept", "Write visitors"}})
+	doc.AddElement(&CodeBlock{Language: "go", Code: "type Element interface {\n\n    Accept(v Visitor)\n}"})
+	doc.AddElement(&Image{URL: "https://example.com/v.png", AltText: "Diagram", Caption: "Structure"})
+	doc.AddElement(&Paragraph{Text: "First line\nsecond line"})
+	return doc
+}
+
+func TestParseMarkdownRoundTrip(t *testing.T) {
+	doc := newRoundTripDocument()
+	want := renderMarkdown(doc)
+	parsed, err := ParseMarkdown(want)
+	if err != nil {
+		t.Fatalf("ParseMarkdown() err = %v", err)
+	}
+	if len(parsed.elements) != len(doc.elements) {
+		t.Errorf("ParseMarkdown() gave %d elements, want %d", len(parsed.elements), len(doc.elements))
+	}
+	if got := renderMarkdown(parsed); got != want {
+		t.Errorf("export -> parse -> export changed the Markdown:\ngot:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestParseMarkdownUnterminatedCodeBlock(t *testing.T) {
+	if _, err := ParseMarkdown("```go\nfmt.Println()\n"); err == nil {
+		t.Error("ParseMarkdown() of an unterminated code block err = nil, want an error")
+	}
+}
+
+// renderJSON exports doc with a fresh JSONExporter
+func renderJSON(doc *Document) string {
+	exporter := &JSONExporter{}
+	doc.Export(exporter)
+	return exporter.GetOutput()
+}
+
+func TestParseDocumentJSONRoundTrip(t *testing.T) {
+	want := renderJSON(newRoundTripDocument())
+	parsed, err := ParseDocumentJSON([]byte(want))
+	if err != nil {
+		t.Fatalf("ParseDocumentJSON() err = %v", err)
+	}
+	if got := renderJSON(parsed); got != want {
+		t.Errorf("export -> parse -> export changed the JSON:\ngot:  %s\nwant: %s", got, want)
+	}
+}
+
+func TestParseDocumentJSONErrors(t *testing.T) {
+	inputs := []string{
+		`not json`,
+		`{"type": "paragraph"}`, // an object, not an array
+		`[{"type": "paragraph", "text": "ok"}, {"type": "marquee"}]`,
+	}
+	for _, input := range inputs {
+		if _, err := ParseDocumentJSON([]byte(input)); err == nil {
+			t.Errorf("ParseDocumentJSON(%s) err = nil, want an error", input)
+		}
+	}
+}
@@ -0,0 +1,182 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ============================================================================
+// VISITOR PATTERN - EXPRESSION TREE EXAMPLE
+// ============================================================================
+// This example walks an arithmetic abstract syntax tree (AST) with two
+// visitors: one evaluates the expression, the other prints it in infix
+// notation with only the parentheses it needs.
+// ============================================================================
+
+// ErrDivisionByZero is reported by the Evaluator
+var ErrDivisionByZero = errors.New("division by zero")
+
+// Visitor defines the visitor interface for expression nodes
+type Visitor interface {
+	VisitNumber(n *NumberNode)
+	VisitBinaryOp(b *BinaryOpNode)
+}
+
+// Node is the element interface
+type Node interface {
+	Accept(v Visitor)
+}
+
+// ============================================================================
+// CONCRETE ELEMENTS - Expression Nodes
+// ============================================================================
+
+// NumberNode is a literal number
+type NumberNode struct {
+	Value float64
+}
+
+func (n *NumberNode) Accept(v Visitor) {
+	v.VisitNumber(n)
+}
+
+// BinaryOpNode applies Op (one of + - * /) to Left and Right
+type BinaryOpNode struct {
+	Op          byte
+	Left, Right Node
+}
+
+func (b *BinaryOpNode) Accept(v Visitor) {
+	v.VisitBinaryOp(b)
+}
+
+// precedence returns how tightly op binds; higher binds tighter
+func precedence(op byte) int {
+	if op == '*' || op == '/' {
+		return 2
+	}
+	return 1
+}
+
+// ============================================================================
+// CONCRETE VISITORS
+// ============================================================================
+
+// Evaluator computes the value of an expression. Each visit leaves the value
+// of the node it visited in Result; the first error stops the evaluation.
+type Evaluator struct {
+	Result float64
+	Err    error
+}
+
+func (e *Evaluator) VisitNumber(n *NumberNode) {
+	e.Result = n.Value
+}
+
+func (e *Evaluator) VisitBinaryOp(b *BinaryOpNode) {
+	b.Left.Accept(e)
+	if e.Err != nil {
+		return
+	}
+	left := e.Result
+	b.Right.Accept(e)
+	if e.Err != nil {
+		return
+	}
+	right := e.Result
+
+	switch b.Op {
+	case '+':
+		e.Result = left + right
+	case '-':
+		e.Result = left - right
+	case '*':
+		e.Result = left * right
+	case '/':
+		if right == 0 {
+			e.Err = ErrDivisionByZero
+			return
+		}
+		e.Result = left / right
+	default:
+		e.Err = fmt.Errorf("unknown operator %q", b.Op)
+	}
+}
+
+// Evaluate runs an Evaluator over expr
+func Evaluate(expr Node) (float64, error) {
+	e := &Evaluator{}
+	expr.Accept(e)
+	return e.Result, e.Err
+}
+
+// Printer writes an expression in infix notation. Parentheses are added only
+// where dropping them would change the meaning: around an operand that binds
+// more loosely than its operator, and around a right operand of - or / with
+// the same precedence, since a - (b - c) is not a - b - c.
+type Printer struct {
+	output strings.Builder
+}
+
+func (p *Printer) VisitNumber(n *NumberNode) {
+	text := strconv.FormatFloat(n.Value, 'g', -1, 64)
+	if n.Value < 0 {
+		text = "(" + text + ")"
+	}
+	p.output.WriteString(text)
+}
+
+func (p *Printer) VisitBinaryOp(b *BinaryOpNode) {
+	p.writeOperand(b.Left, precedence(b.Op), false)
+	fmt.Fprintf(&p.output, " %c ", b.Op)
+	nonAssociative := b.Op == '-' || b.Op == '/'
+	p.writeOperand(b.Right, precedence(b.Op), nonAssociative)
+}
+
+func (p *Printer) writeOperand(operand Node, parent int, parenOnEqual bool) {
+	needParens := false
+	if op, ok := operand.(*BinaryOpNode); ok {
+		child := precedence(op.Op)
+		needParens = child < parent || (parenOnEqual && child == parent)
+	}
+	if needParens {
+		p.output.WriteString("(")
+	}
+	operand.Accept(p)
+	if needParens {
+		p.output.WriteString(")")
+	}
+}
+
+func (p *Printer) GetOutput() string {
+	return p.output.String()
+}
+
+// Print runs a Printer over expr
+func Print(expr Node) string {
+	p := &Printer{}
+	expr.Accept(p)
+	return p.GetOutput()
+}
+
+// ============================================================================
+// DEMO
+// ============================================================================
+
+func num(v float64) *NumberNode { return &NumberNode{Value: v} }
+
+func op(o byte, left, right Node) *BinaryOpNode {
+	return &BinaryOpNode{Op: o, Left: left, Right: right}
+}
+
+func main() {
+	// (1 + (5 - 2) * 4) / 2
+	expr := op('/', op('+', num(1), op('*', op('-', num(5), num(2)), num(4))), num(2))
+	value, err := Evaluate(expr)
+	fmt.Println(Print(expr), "=", value, err) // 6.5 <nil>
+
+	_, err = Evaluate(op('/', num(1), op('-', num(3), num(3))))
+	fmt.Println("1 / (3 - 3):", err)
+}
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestExpressionPrintAndEvaluate(t *testing.T) {
+	tests := []struct {
+		expr      Node
+		wantText  string
+		wantValue float64
+		wantErr   error
+	}{
+		{num(42), "42", 42, nil},
+		{op('+', num(1), op('*', num(2), num(3))), "1 + 2 * 3", 7, nil},
+		{op('*', op('+', num(1), num(2)), num(3)), "(1 + 2) * 3", 9, nil},
+		{op('-', op('-', num(10), num(4)), num(3)), "10 - 4 - 3", 3, nil},
+		{op('-', num(10), op('-', num(4), num(3))), "10 - (4 - 3)", 9, nil},
+		{op('/', num(8), op('*', num(2), num(2))), "8 / (2 * 2)", 2, nil},
+		{op('+', num(1), op('+', num(2), num(3))), "1 + 2 + 3", 6, nil},
+		{op('*', num(-2), op('-', num(1), num(4))), "(-2) * (1 - 4)", 6, nil},
+		{op('/', op('+', num(1), op('*', op('-', num(5), num(2)), num(4))), num(2)),
+			"(1 + (5 - 2) * 4) / 2", 6.5, nil},
+		{op('/', num(1), num(0)), "1 / 0", 0, ErrDivisionByZero},
+		{op('+', num(1), op('/', num(2), op('-', num(3), num(3)))), "1 + 2 / (3 - 3)", 0, ErrDivisionByZero},
+	}
+	for _, tt := range tests {
+		text := Print(tt.expr)
+		if text != tt.wantText {
+			t.Errorf("Print() = %q, want %q", text, tt.wantText)
+		}
+		value, err := Evaluate(tt.expr)
+		if !errors.Is(err, tt.wantErr) {
+			t.Errorf("Evaluate(%s) err = %v, want %v", text, err, tt.wantErr)
+		} else if err == nil && value != tt.wantValue {
+			t.Errorf("Evaluate(%s) = %g, want %g", text, value, tt.wantValue)
+		}
+	}
+}
+
+func TestEvaluateUnknownOperator(t *testing.T) {
+	_, err := Evaluate(op('%', num(7), num(2)))
+	if err == nil || errors.Is(err, ErrDivisionByZero) {
+		t.Errorf("Evaluate(7 %% 2) err = %v, want an unknown operator error", err)
+	}
+}
+
+// Parentheses are dropped only where the grouping doesn't change the value:
+// - and / are not associative, so a grouped right operand keeps them
+func TestPrinterKeepsNeededParentheses(t *testing.T) {
+	tests := []struct {
+		expr Node
+		want string
+	}{
+		{op('-', num(8), op('+', num(2), num(1))), "8 - (2 + 1)"},
+		{op('+', op('-', num(8), num(2)), num(1)), "8 - 2 + 1"},
+		{op('/', num(8), op('/', num(4), num(2))), "8 / (4 / 2)"},
+		{op('/', op('/', num(8), num(4)), num(2)), "8 / 4 / 2"},
+		{op('*', num(2), op('*', num(3), num(4))), "2 * 3 * 4"},
+		{op('*', op('+', num(1), num(2)), op('-', num(3), num(4))), "(1 + 2) * (3 - 4)"},
+	}
+	for _, tt := range tests {
+		if got := Print(tt.expr); got != tt.want {
+			t.Errorf("Print() = %q, want %q", got, tt.want)
+		}
+	}
+}
@@ -1,6 +1,11 @@
 package main
 
-import "fmt"
+import (
+	"fmt"
+	"math"
+	"strings"
+	"sync"
+)
 
 // ============================================================================
 // VISITOR PATTERN - E-COMMERCE EXAMPLE
@@ -92,106 +97,381 @@ func (b *Book) GetPrice() float64 {
 // CONCRETE VISITORS - Different Operations
 // ============================================================================
 
+// QuantityAware is implemented by visitors that can scale a single visit by
+// the quantity of a cart line instead of being visited once per unit
+type QuantityAware interface {
+	SetQuantity(n int)
+}
+
+// lineQuantity is embedded by visitors to implement QuantityAware.
+// The zero value means a quantity of one.
+type lineQuantity struct {
+	qty int
+}
+
+func (q *lineQuantity) SetQuantity(n int) {
+	q.qty = n
+}
+
+func (q *lineQuantity) quantity() int {
+	if q.qty < 1 {
+		return 1
+	}
+	return q.qty
+}
+
+// label returns the product name, suffixed with the quantity when above one
+func (q *lineQuantity) label(name string) string {
+	if q.quantity() == 1 {
+		return name
+	}
+	return fmt.Sprintf("%s x%d", name, q.quantity())
+}
+
+// Product categories used to look up per-category rates
+const (
+	CategoryElectronics = "electronics"
+	CategoryClothing    = "clothing"
+	CategoryBooks       = "books"
+)
+
+// DefaultTaxRates are the rates used when no jurisdiction is configured
+var DefaultTaxRates = map[string]float64{
+	CategoryElectronics: 0.15, // 15% tax on electronics
+	CategoryClothing:    0.08, // 8% tax on clothing
+	CategoryBooks:       0.05, // 5% tax on books
+}
+
 // TaxCalculator calculates tax for different product types
 type TaxCalculator struct {
+	lineQuantity
 	TotalTax float64
+	Rates    map[string]float64 // tax rate per product category
+	Currency string             // currency symbol used when printing
+}
+
+// NewTaxCalculator creates a tax calculator for the given category rates
+// and currency symbol. A nil map falls back to DefaultTaxRates and an empty
+// currency to "$"; a category missing from a non-nil map is not taxed.
+func NewTaxCalculator(rates map[string]float64, currency string) *TaxCalculator {
+	if rates == nil {
+		rates = DefaultTaxRates
+	}
+	if currency == "" {
+		currency = "$"
+	}
+	return &TaxCalculator{Rates: rates, Currency: currency}
+}
+
+func (tc *TaxCalculator) rate(category string) float64 {
+	if tc.Rates == nil {
+		return DefaultTaxRates[category]
+	}
+	return tc.Rates[category]
+}
+
+func (tc *TaxCalculator) currency() string {
+	if tc.Currency == "" {
+		return "$"
+	}
+	return tc.Currency
 }
 
 func (tc *TaxCalculator) VisitElectronics(e *Electronics) {
-	tax := e.Price * 0.15 // 15% tax on electronics
+	rate := tc.rate(CategoryElectronics)
+	qty := float64(tc.quantity())
+	tax := e.Price * qty * rate
 	tc.TotalTax += tax
-	fmt.Printf("  🔌 %s: $%.2f (Tax: $%.2f @ 15%%)\n", e.Name, e.Price, tax)
+	cur := tc.currency()
+	fmt.Printf("  🔌 %s: %s%.2f (Tax: %s%.2f @ %g%%)\n", tc.label(e.Name), cur, e.Price*qty, cur, tax, rate*100)
 }
 
 func (tc *TaxCalculator) VisitClothing(c *Clothing) {
-	tax := c.Price * 0.08 // 8% tax on clothing
+	rate := tc.rate(CategoryClothing)
+	qty := float64(tc.quantity())
+	tax := c.Price * qty * rate
 	tc.TotalTax += tax
-	fmt.Printf("  👕 %s: $%.2f (Tax: $%.2f @ 8%%)\n", c.Name, c.Price, tax)
+	cur := tc.currency()
+	fmt.Printf("  👕 %s: %s%.2f (Tax: %s%.2f @ %g%%)\n", tc.label(c.Name), cur, c.Price*qty, cur, tax, rate*100)
 }
 
 func (tc *TaxCalculator) VisitBook(b *Book) {
-	tax := b.Price * 0.05 // 5% tax on books
+	rate := tc.rate(CategoryBooks)
+	qty := float64(tc.quantity())
+	tax := b.Price * qty * rate
 	tc.TotalTax += tax
-	fmt.Printf("  📚 %s: $%.2f (Tax: $%.2f @ 5%%)\n", b.Name, b.Price, tax)
+	cur := tc.currency()
+	fmt.Printf("  📚 %s: %s%.2f (Tax: %s%.2f @ %g%%)\n", tc.label(b.Name), cur, b.Price*qty, cur, tax, rate*100)
 }
 
 // ShippingCalculator calculates shipping costs for different product types
 type ShippingCalculator struct {
+	lineQuantity
 	TotalShipping float64
 }
 
+// electronicsShipping is a flat $15 for electronics (fragile)
+func electronicsShipping(e *Electronics) float64 {
+	return 15.0
+}
+
+// clothingShipping is a flat $5 for clothing (lightweight)
+func clothingShipping(c *Clothing) float64 {
+	return 5.0
+}
+
+// bookShipping is $3 base + $0.01 per page
+func bookShipping(b *Book) float64 {
+	return 3.0 + (float64(b.Pages) * 0.01)
+}
+
 func (sc *ShippingCalculator) VisitElectronics(e *Electronics) {
-	shipping := 15.0 // Flat $15 for electronics (fragile)
+	shipping := electronicsShipping(e) * float64(sc.quantity())
 	sc.TotalShipping += shipping
-	fmt.Printf("  🔌 %s: $%.2f shipping (fragile item)\n", e.Name, shipping)
+	fmt.Printf("  🔌 %s: $%.2f shipping (fragile item)\n", sc.label(e.Name), shipping)
 }
 
 func (sc *ShippingCalculator) VisitClothing(c *Clothing) {
-	shipping := 5.0 // Flat $5 for clothing (lightweight)
+	shipping := clothingShipping(c) * float64(sc.quantity())
 	sc.TotalShipping += shipping
-	fmt.Printf("  👕 %s: $%.2f shipping (lightweight)\n", c.Name, shipping)
+	fmt.Printf("  👕 %s: $%.2f shipping (lightweight)\n", sc.label(c.Name), shipping)
 }
 
 func (sc *ShippingCalculator) VisitBook(b *Book) {
-	// Books: $3 base + $0.01 per page
-	shipping := 3.0 + (float64(b.Pages) * 0.01)
+	shipping := bookShipping(b) * float64(sc.quantity())
 	sc.TotalShipping += shipping
-	fmt.Printf("  📚 %s: $%.2f shipping (%d pages)\n", b.Name, shipping, b.Pages)
+	fmt.Printf("  📚 %s: $%.2f shipping (%d pages)\n", sc.label(b.Name), shipping, b.Pages)
 }
 
-// DiscountCalculator calculates available discounts
+// DiscountRule is a promotion applied to a single unit of a product. It
+// returns the discount amount and a short reason when it applies.
+type DiscountRule func(item Element) (amount float64, reason string, applies bool)
+
+// DiscountCalculator calculates available discounts by running every rule
+// against each product and adding up the ones that apply
 type DiscountCalculator struct {
+	lineQuantity
+	Rules         []DiscountRule // nil uses DefaultDiscountRules
 	TotalDiscount float64
 }
 
-func (dc *DiscountCalculator) VisitElectronics(e *Electronics) {
-	// 10% discount if warranty > 24 months
-	var discount float64
+// NewDiscountCalculator creates a DiscountCalculator with the given rules
+func NewDiscountCalculator(rules ...DiscountRule) *DiscountCalculator {
+	return &DiscountCalculator{Rules: rules}
+}
+
+// ExtendedWarrantyDiscount, CottonDiscount and HardcoverDiscount are the
+// built-in per-category promotions
+var (
+	ExtendedWarrantyDiscount DiscountRule = func(item Element) (float64, string, bool) {
+		if e, ok := item.(*Electronics); ok {
+			amount, applies := electronicsDiscount(e)
+			return amount, "extended warranty", applies
+		}
+		return 0, "", false
+	}
+	CottonDiscount DiscountRule = func(item Element) (float64, string, bool) {
+		if c, ok := item.(*Clothing); ok {
+			amount, applies := clothingDiscount(c)
+			return amount, "cotton material", applies
+		}
+		return 0, "", false
+	}
+	HardcoverDiscount DiscountRule = func(item Element) (float64, string, bool) {
+		if b, ok := item.(*Book); ok {
+			amount, applies := bookDiscount(b)
+			return amount, "hardcover", applies
+		}
+		return 0, "", false
+	}
+)
+
+// DefaultDiscountRules are used by a DiscountCalculator with no Rules set
+var DefaultDiscountRules = []DiscountRule{ExtendedWarrantyDiscount, CottonDiscount, HardcoverDiscount}
+
+// PercentOffEverything returns a rule taking percent off every product
+func PercentOffEverything(percent float64) DiscountRule {
+	return func(item Element) (float64, string, bool) {
+		return item.GetPrice() * percent / 100, fmt.Sprintf("%g%% off everything", percent), true
+	}
+}
+
+// applyDiscountRules returns the combined per-unit discount of every rule
+// that applies to item, along with their reasons
+func applyDiscountRules(rules []DiscountRule, item Element) (float64, []string) {
+	total := 0.0
+	var reasons []string
+	for _, rule := range rules {
+		if amount, reason, applies := rule(item); applies {
+			total += amount
+			reasons = append(reasons, reason)
+		}
+	}
+	return total, reasons
+}
+
+// electronicsDiscount gives 10% off if warranty > 24 months
+func electronicsDiscount(e *Electronics) (float64, bool) {
 	if e.Warranty > 24 {
-		discount = e.Price * 0.10
-		dc.TotalDiscount += discount
-		fmt.Printf("  🔌 %s: -$%.2f discount (extended warranty)\n", e.Name, discount)
-	} else {
-		fmt.Printf("  🔌 %s: No discount available\n", e.Name)
+		return e.Price * 0.10, true
 	}
+	return 0, false
 }
 
-func (dc *DiscountCalculator) VisitClothing(c *Clothing) {
-	// 15% discount on cotton material
-	var discount float64
+// clothingDiscount gives 15% off cotton material
+func clothingDiscount(c *Clothing) (float64, bool) {
 	if c.Material == "Cotton" {
-		discount = c.Price * 0.15
-		dc.TotalDiscount += discount
-		fmt.Printf("  👕 %s: -$%.2f discount (cotton material)\n", c.Name, discount)
-	} else {
-		fmt.Printf("  👕 %s: No discount available\n", c.Name)
+		return c.Price * 0.15, true
 	}
+	return 0, false
 }
 
-func (dc *DiscountCalculator) VisitBook(b *Book) {
-	// 20% discount on hardcover books
-	var discount float64
+// bookDiscount gives 20% off hardcover books
+func bookDiscount(b *Book) (float64, bool) {
 	if b.Hardcover {
-		discount = b.Price * 0.20
-		dc.TotalDiscount += discount
-		fmt.Printf("  📚 %s: -$%.2f discount (hardcover)\n", b.Name, discount)
-	} else {
-		fmt.Printf("  📚 %s: No discount available\n", b.Name)
+		return b.Price * 0.20, true
+	}
+	return 0, false
+}
+
+func (dc *DiscountCalculator) apply(icon string, item Element) {
+	rules := dc.Rules
+	if rules == nil {
+		rules = DefaultDiscountRules
 	}
+	discount, reasons := applyDiscountRules(rules, item)
+	if len(reasons) == 0 {
+		fmt.Printf("  %s %s: No discount available\n", icon, dc.label(item.GetName()))
+		return
+	}
+	discount *= float64(dc.quantity())
+	dc.TotalDiscount += discount
+	fmt.Printf("  %s %s: -$%.2f discount (%s)\n", icon, dc.label(item.GetName()), discount, strings.Join(reasons, ", "))
+}
+
+func (dc *DiscountCalculator) VisitElectronics(e *Electronics) {
+	dc.apply("🔌", e)
+}
+
+func (dc *DiscountCalculator) VisitClothing(c *Clothing) {
+	dc.apply("👕", c)
+}
+
+func (dc *DiscountCalculator) VisitBook(b *Book) {
+	dc.apply("📚", b)
+}
+
+// LineItem is a single product entry on a receipt
+type LineItem struct {
+	Name     string
+	Quantity int
+	Price    float64 // unit price multiplied by quantity
+	Tax      float64
+	Shipping float64
+	Discount float64
+}
+
+// Receipt is the structured result of checking out a cart
+type Receipt struct {
+	LineItems []LineItem
+	Subtotal  float64
+	Tax       float64
+	Shipping  float64
+	Discount  float64
+	Total     float64
+}
+
+// ReceiptVisitor builds a Receipt by combining the tax, shipping and
+// discount rules without printing anything
+type ReceiptVisitor struct {
+	lineQuantity
+	Taxes   *TaxCalculator // rates to apply; nil uses DefaultTaxRates
+	Rules   []DiscountRule // nil uses DefaultDiscountRules
+	receipt Receipt
+}
+
+func (rv *ReceiptVisitor) taxRate(category string) float64 {
+	if rv.Taxes == nil {
+		return DefaultTaxRates[category]
+	}
+	return rv.Taxes.rate(category)
+}
+
+func (rv *ReceiptVisitor) discount(item Element) float64 {
+	rules := rv.Rules
+	if rules == nil {
+		rules = DefaultDiscountRules
+	}
+	discount, _ := applyDiscountRules(rules, item)
+	return discount
+}
+
+func (rv *ReceiptVisitor) addLine(item LineItem) {
+	qty := float64(rv.quantity())
+	item.Quantity = rv.quantity()
+	item.Price *= qty
+	item.Tax *= qty
+	item.Shipping *= qty
+	item.Discount *= qty
+	rv.receipt.LineItems = append(rv.receipt.LineItems, item)
+	rv.receipt.Subtotal += item.Price
+	rv.receipt.Tax += item.Tax
+	rv.receipt.Shipping += item.Shipping
+	rv.receipt.Discount += item.Discount
+	rv.receipt.Total = rv.receipt.Subtotal + rv.receipt.Tax + rv.receipt.Shipping - rv.receipt.Discount
+}
+
+func (rv *ReceiptVisitor) VisitElectronics(e *Electronics) {
+	rv.addLine(LineItem{
+		Name:     e.Name,
+		Price:    e.Price,
+		Tax:      e.Price * rv.taxRate(CategoryElectronics),
+		Shipping: electronicsShipping(e),
+		Discount: rv.discount(e),
+	})
+}
+
+func (rv *ReceiptVisitor) VisitClothing(c *Clothing) {
+	rv.addLine(LineItem{
+		Name:     c.Name,
+		Price:    c.Price,
+		Tax:      c.Price * rv.taxRate(CategoryClothing),
+		Shipping: clothingShipping(c),
+		Discount: rv.discount(c),
+	})
+}
+
+func (rv *ReceiptVisitor) VisitBook(b *Book) {
+	rv.addLine(LineItem{
+		Name:     b.Name,
+		Price:    b.Price,
+		Tax:      b.Price * rv.taxRate(CategoryBooks),
+		Shipping: bookShipping(b),
+		Discount: rv.discount(b),
+	})
+}
+
+// Receipt returns the receipt accumulated from the visited items
+func (rv *ReceiptVisitor) Receipt() Receipt {
+	return rv.receipt
 }
 
 // InfoPrinter prints detailed information about products
-type InfoPrinter struct{}
+type InfoPrinter struct {
+	lineQuantity
+}
 
 func (ip *InfoPrinter) VisitElectronics(e *Electronics) {
 	fmt.Printf("  🔌 Electronics: %s\n", e.Name)
 	fmt.Printf("     Price: $%.2f\n", e.Price)
+	fmt.Printf("     Quantity: %d\n", ip.quantity())
 	fmt.Printf("     Warranty: %d months\n", e.Warranty)
 }
 
 func (ip *InfoPrinter) VisitClothing(c *Clothing) {
 	fmt.Printf("  👕 Clothing: %s\n", c.Name)
 	fmt.Printf("     Price: $%.2f\n", c.Price)
+	fmt.Printf("     Quantity: %d\n", ip.quantity())
 	fmt.Printf("     Size: %s\n", c.Size)
 	fmt.Printf("     Material: %s\n", c.Material)
 }
@@ -199,6 +479,7 @@ func (ip *InfoPrinter) VisitClothing(c *Clothing) {
 func (ip *InfoPrinter) VisitBook(b *Book) {
 	fmt.Printf("  📚 Book: %s\n", b.Name)
 	fmt.Printf("     Price: $%.2f\n", b.Price)
+	fmt.Printf("     Quantity: %d\n", ip.quantity())
 	fmt.Printf("     Author: %s\n", b.Author)
 	fmt.Printf("     Pages: %d\n", b.Pages)
 	fmt.Printf("     Type: ")
@@ -213,24 +494,104 @@ func (ip *InfoPrinter) VisitBook(b *Book) {
 // SHOPPING CART - Client Code
 // ============================================================================
 
+// CartLine is a product together with how many units of it are in the cart
+type CartLine struct {
+	Item     Element
+	Quantity int
+}
+
+// ShoppingCart is safe for concurrent use, e.g. by several requests sharing
+// one cart
 type ShoppingCart struct {
-	items []Element
+	mu    sync.RWMutex
+	lines []CartLine
 }
 
+// AddItem adds a single unit of item to the cart
 func (cart *ShoppingCart) AddItem(item Element) {
-	cart.items = append(cart.items, item)
+	cart.AddQuantity(item, 1)
+}
+
+// AddQuantity adds quantity units of item, merging with an existing line
+// for a product of the same name
+func (cart *ShoppingCart) AddQuantity(item Element, quantity int) {
+	if quantity < 1 {
+		return
+	}
+	cart.mu.Lock()
+	defer cart.mu.Unlock()
+	for i := range cart.lines {
+		if cart.lines[i].Item.GetName() == item.GetName() {
+			cart.lines[i].Quantity += quantity
+			return
+		}
+	}
+	cart.lines = append(cart.lines, CartLine{Item: item, Quantity: quantity})
+}
+
+// RemoveItem removes one unit of the first item with the given name and
+// reports whether anything was removed
+func (cart *ShoppingCart) RemoveItem(name string) bool {
+	cart.mu.Lock()
+	defer cart.mu.Unlock()
+	for i, line := range cart.lines {
+		if line.Item.GetName() == name {
+			if line.Quantity > 1 {
+				cart.lines[i].Quantity--
+			} else {
+				cart.lines = append(cart.lines[:i], cart.lines[i+1:]...)
+			}
+			return true
+		}
+	}
+	return false
 }
 
+// Count returns the number of units in the cart
+func (cart *ShoppingCart) Count() int {
+	cart.mu.RLock()
+	defer cart.mu.RUnlock()
+	count := 0
+	for _, line := range cart.lines {
+		count += line.Quantity
+	}
+	return count
+}
+
+// Lines returns a copy of the cart lines
+func (cart *ShoppingCart) Lines() []CartLine {
+	cart.mu.RLock()
+	defer cart.mu.RUnlock()
+	return append([]CartLine(nil), cart.lines...)
+}
+
+// ApplyVisitor visits every cart line. Visitors implementing QuantityAware
+// are visited once per line with the quantity set; other visitors are
+// visited once per unit. The visitor runs on a snapshot of the lines, so the
+// cart is not locked while it works and can change in the meantime.
 func (cart *ShoppingCart) ApplyVisitor(v Visitor) {
-	for _, item := range cart.items {
-		item.Accept(v)
+	qv, aware := v.(QuantityAware)
+	for _, line := range cart.Lines() {
+		if aware {
+			qv.SetQuantity(line.Quantity)
+			line.Item.Accept(v)
+			continue
+		}
+		for i := 0; i < line.Quantity; i++ {
+			line.Item.Accept(v)
+		}
+	}
+	if aware {
+		qv.SetQuantity(1)
 	}
 }
 
 func (cart *ShoppingCart) GetTotalPrice() float64 {
+	cart.mu.RLock()
+	defer cart.mu.RUnlock()
 	total := 0.0
-	for _, item := range cart.items {
-		total += item.GetPrice()
+	for _, line := range cart.lines {
+		total += line.Item.GetPrice() * float64(line.Quantity)
 	}
 	return total
 }
@@ -261,12 +622,12 @@ func main() {
 		Warranty: 12,
 	})
 
-	cart.AddItem(&Clothing{
+	cart.AddQuantity(&Clothing{
 		Name:     "T-Shirt",
 		Price:    29.99,
 		Size:     "M",
 		Material: "Cotton",
-	})
+	}, 2)
 
 	cart.AddItem(&Clothing{
 		Name:     "Jeans",
@@ -306,7 +667,7 @@ func main() {
 	// Calculate tax
 	fmt.Println("🧾 TAX CALCULATION:")
 	fmt.Println("─────────────────────────────────────────────────────────")
-	taxCalc := &TaxCalculator{}
+	taxCalc := NewTaxCalculator(nil, "$")
 	cart.ApplyVisitor(taxCalc)
 	fmt.Printf("\n💳 Total Tax: $%.2f\n", taxCalc.TotalTax)
 	fmt.Println()
@@ -338,6 +699,26 @@ func main() {
 	fmt.Printf("💳 FINAL TOTAL:     $%8.2f\n", finalTotal)
 	fmt.Println("═══════════════════════════════════════════════════════════")
 
+	// Build the same totals as data instead of printed output
+	receiptVisitor := &ReceiptVisitor{Taxes: taxCalc}
+	cart.ApplyVisitor(receiptVisitor)
+	receipt := receiptVisitor.Receipt()
+	fmt.Printf("\n🧾 Receipt: %d line items, total $%.2f\n", len(receipt.LineItems), receipt.Total)
+
+	// Swap in a store-wide promotion without touching the visitor
+	fmt.Println()
+	fmt.Println("🏷️  STORE-WIDE PROMOTION:")
+	fmt.Println("─────────────────────────────────────────────────────────")
+	promoCalc := NewDiscountCalculator(PercentOffEverything(10))
+	cart.ApplyVisitor(promoCalc)
+	want := subtotal * 0.10
+	fmt.Printf("\n💝 Total Discount: $%.2f (expected $%.2f, match: %t)\n",
+		promoCalc.TotalDiscount, want, math.Abs(promoCalc.TotalDiscount-want) < 1e-9)
+	promoReceipt := &ReceiptVisitor{Taxes: taxCalc, Rules: promoCalc.Rules}
+	cart.ApplyVisitor(promoReceipt)
+	fmt.Printf("🧾 Promotion receipt discount: $%.2f (matches calculator: %t)\n",
+		promoReceipt.Receipt().Discount, math.Abs(promoReceipt.Receipt().Discount-promoCalc.TotalDiscount) < 1e-9)
+
 	fmt.Println()
 	fmt.Println("✨ Key Takeaway:")
 	fmt.Println("   We added 4 different operations (Info, Tax, Shipping, Discount)")
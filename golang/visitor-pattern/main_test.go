@@ -0,0 +1,279 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"sync"
+	"testing"
+)
+
+// approxEqual compares money amounts that went through float arithmetic
+func approxEqual(a, b float64) bool {
+	return math.Abs(a-b) < 1e-9
+}
+
+// newTestCart returns a cart holding one product of each category
+func newTestCart() *ShoppingCart {
+	cart := &ShoppingCart{}
+	cart.AddItem(&Electronics{Name: "Laptop", Price: 1000, Warranty: 36})
+	cart.AddItem(&Clothing{Name: "T-Shirt", Price: 20, Size: "M", Material: "Cotton"})
+	cart.AddItem(&Book{Name: "Clean Code", Price: 40, Pages: 400, Hardcover: true})
+	return cart
+}
+
+func TestTaxCalculatorZeroRates(t *testing.T) {
+	zeroTax := map[string]float64{
+		CategoryElectronics: 0,
+		CategoryClothing:    0,
+		CategoryBooks:       0,
+	}
+	taxCalc := NewTaxCalculator(zeroTax, "€")
+	newTestCart().ApplyVisitor(taxCalc)
+	if taxCalc.TotalTax != 0 {
+		t.Errorf("TotalTax = %v, want 0", taxCalc.TotalTax)
+	}
+}
+
+func TestTaxCalculatorDefaults(t *testing.T) {
+	taxCalc := NewTaxCalculator(nil, "")
+	if taxCalc.Currency != "$" {
+		t.Errorf("Currency = %q, want %q", taxCalc.Currency, "$")
+	}
+	newTestCart().ApplyVisitor(taxCalc)
+	// 15% of 1000 + 8% of 20 + 5% of 40
+	if want := 150 + 1.6 + 2.0; !approxEqual(taxCalc.TotalTax, want) {
+		t.Errorf("TotalTax = %v, want %v", taxCalc.TotalTax, want)
+	}
+
+	// The zero value behaves like the defaults too
+	zero := &TaxCalculator{}
+	newTestCart().ApplyVisitor(zero)
+	if !approxEqual(zero.TotalTax, taxCalc.TotalTax) {
+		t.Errorf("zero value TotalTax = %v, want %v", zero.TotalTax, taxCalc.TotalTax)
+	}
+}
+
+func TestTaxCalculatorMissingCategoryIsUntaxed(t *testing.T) {
+	taxCalc := NewTaxCalculator(map[string]float64{CategoryBooks: 0.10}, "$")
+	newTestCart().ApplyVisitor(taxCalc)
+	if want := 4.0; !approxEqual(taxCalc.TotalTax, want) {
+		t.Errorf("TotalTax = %v, want %v", taxCalc.TotalTax, want)
+	}
+}
+
+func TestReceiptEmptyCart(t *testing.T) {
+	rv := &ReceiptVisitor{}
+	(&ShoppingCart{}).ApplyVisitor(rv)
+	receipt := rv.Receipt()
+	if len(receipt.LineItems) != 0 {
+		t.Errorf("got %d line items, want 0", len(receipt.LineItems))
+	}
+	totals := []float64{receipt.Subtotal, receipt.Tax, receipt.Shipping, receipt.Discount, receipt.Total}
+	for _, total := range totals {
+		if total != 0 {
+			t.Errorf("receipt = %+v, want all zeros", receipt)
+			break
+		}
+	}
+}
+
+func TestReceiptMatchesCalculators(t *testing.T) {
+	cart := newTestCart()
+	taxCalc := NewTaxCalculator(nil, "$")
+	shippingCalc := &ShippingCalculator{}
+	discountCalc := &DiscountCalculator{}
+	cart.ApplyVisitor(taxCalc)
+	cart.ApplyVisitor(shippingCalc)
+	cart.ApplyVisitor(discountCalc)
+	subtotal := cart.GetTotalPrice()
+
+	rv := &ReceiptVisitor{Taxes: taxCalc}
+	cart.ApplyVisitor(rv)
+	receipt := rv.Receipt()
+
+	if len(receipt.LineItems) != 3 {
+		t.Fatalf("got %d line items, want 3", len(receipt.LineItems))
+	}
+	checks := []struct {
+		name      string
+		got, want float64
+	}{
+		{"Subtotal", receipt.Subtotal, subtotal},
+		{"Tax", receipt.Tax, taxCalc.TotalTax},
+		{"Shipping", receipt.Shipping, shippingCalc.TotalShipping},
+		{"Discount", receipt.Discount, discountCalc.TotalDiscount},
+		{"Total", receipt.Total, subtotal + taxCalc.TotalTax + shippingCalc.TotalShipping - discountCalc.TotalDiscount},
+	}
+	for _, c := range checks {
+		if !approxEqual(c.got, c.want) {
+			t.Errorf("%s = %v, want %v", c.name, c.got, c.want)
+		}
+	}
+}
+
+func TestShoppingCartRemoveItem(t *testing.T) {
+	empty := &ShoppingCart{}
+	if empty.RemoveItem("Laptop") {
+		t.Error("RemoveItem on an empty cart = true, want false")
+	}
+	if empty.Count() != 0 {
+		t.Errorf("empty cart Count() = %d, want 0", empty.Count())
+	}
+
+	cart := newTestCart()
+	if cart.RemoveItem("Missing") {
+		t.Error("RemoveItem of a name not in the cart = true, want false")
+	}
+	if cart.Count() != 3 {
+		t.Errorf("Count() = %d, want 3", cart.Count())
+	}
+	if !cart.RemoveItem("T-Shirt") {
+		t.Error("RemoveItem(T-Shirt) = false, want true")
+	}
+	if cart.Count() != 2 {
+		t.Errorf("Count() after remove = %d, want 2", cart.Count())
+	}
+	if cart.RemoveItem("T-Shirt") {
+		t.Error("second RemoveItem(T-Shirt) = true, want false")
+	}
+}
+
+// unitVisits counts how often each product is visited
+type unitVisits map[string]int
+
+func (u unitVisits) VisitElectronics(e *Electronics) { u[e.Name]++ }
+func (u unitVisits) VisitClothing(c *Clothing)       { u[c.Name]++ }
+func (u unitVisits) VisitBook(b *Book)               { u[b.Name]++ }
+
+func TestShoppingCartAggregatesQuantities(t *testing.T) {
+	cart := &ShoppingCart{}
+	cart.AddItem(&Electronics{Name: "Laptop", Price: 1000, Warranty: 36})
+	cart.AddItem(&Electronics{Name: "Laptop", Price: 1000, Warranty: 36})
+	cart.AddQuantity(&Book{Name: "Go", Price: 30, Pages: 300}, 3)
+	cart.AddQuantity(&Book{Name: "Go", Price: 30, Pages: 300}, 0)
+
+	lines := cart.Lines()
+	if len(lines) != 2 {
+		t.Fatalf("got %d lines, want 2", len(lines))
+	}
+	if lines[0].Quantity != 2 || lines[1].Quantity != 3 {
+		t.Errorf("quantities = %d, %d, want 2, 3", lines[0].Quantity, lines[1].Quantity)
+	}
+	if cart.Count() != 5 {
+		t.Errorf("Count() = %d, want 5", cart.Count())
+	}
+	if want := 2*1000.0 + 3*30.0; !approxEqual(cart.GetTotalPrice(), want) {
+		t.Errorf("GetTotalPrice() = %v, want %v", cart.GetTotalPrice(), want)
+	}
+
+	// Removing takes one unit off the line before dropping it
+	cart.RemoveItem("Laptop")
+	if got := cart.Lines()[0].Quantity; got != 1 {
+		t.Errorf("Laptop quantity after one remove = %d, want 1", got)
+	}
+	cart.AddItem(&Electronics{Name: "Laptop", Price: 1000, Warranty: 36})
+
+	// A visitor that isn't QuantityAware is visited once per unit
+	visits := unitVisits{}
+	cart.ApplyVisitor(visits)
+	if visits["Laptop"] != 2 || visits["Go"] != 3 {
+		t.Errorf("visits = %v, want Laptop:2 Go:3", visits)
+	}
+
+	// QuantityAware visitors multiply by the line quantity in one visit
+	taxCalc := NewTaxCalculator(nil, "$")
+	shippingCalc := &ShippingCalculator{}
+	discountCalc := &DiscountCalculator{}
+	cart.ApplyVisitor(taxCalc)
+	cart.ApplyVisitor(shippingCalc)
+	cart.ApplyVisitor(discountCalc)
+	checks := []struct {
+		name      string
+		got, want float64
+	}{
+		{"TotalTax", taxCalc.TotalTax, 2*1000*0.15 + 3*30*0.05},
+		{"TotalShipping", shippingCalc.TotalShipping, 2*15.0 + 3*(3.0+300*0.01)},
+		{"TotalDiscount", discountCalc.TotalDiscount, 2 * 1000 * 0.10},
+	}
+	for _, c := range checks {
+		if !approxEqual(c.got, c.want) {
+			t.Errorf("%s = %v, want %v", c.name, c.got, c.want)
+		}
+	}
+}
+
+func TestDiscountRules(t *testing.T) {
+	tests := []struct {
+		name  string
+		rules []DiscountRule
+		want  float64
+	}{
+		// 10% of 1000 warranty, 15% of 20 cotton, 20% of 40 hardcover
+		{name: "defaults", rules: nil, want: 100 + 3 + 8},
+		{name: "10% off everything", rules: []DiscountRule{PercentOffEverything(10)}, want: 106},
+		{name: "defaults plus 10% off", rules: append([]DiscountRule{PercentOffEverything(10)}, DefaultDiscountRules...), want: 106 + 111},
+		{name: "no rules", rules: []DiscountRule{}, want: 0},
+	}
+	for _, tt := range tests {
+		calc := NewDiscountCalculator(tt.rules...)
+		newTestCart().ApplyVisitor(calc)
+		if !approxEqual(calc.TotalDiscount, tt.want) {
+			t.Errorf("%s: TotalDiscount = %v, want %v", tt.name, calc.TotalDiscount, tt.want)
+		}
+
+		receipt := &ReceiptVisitor{Rules: tt.rules}
+		newTestCart().ApplyVisitor(receipt)
+		if got := receipt.Receipt().Discount; !approxEqual(got, tt.want) {
+			t.Errorf("%s: receipt Discount = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+// unitCounter is a silent visitor that counts the units it visits
+type unitCounter struct {
+	units int
+}
+
+func (u *unitCounter) VisitElectronics(e *Electronics) { u.units++ }
+func (u *unitCounter) VisitClothing(c *Clothing)       { u.units++ }
+func (u *unitCounter) VisitBook(b *Book)               { u.units++ }
+
+// Run with -race: goroutines add to and remove from one cart while others
+// apply visitors and read its totals
+func TestShoppingCartConcurrentAccess(t *testing.T) {
+	cart := &ShoppingCart{}
+	const adders, addsEach = 8, 50
+	var wg sync.WaitGroup
+	for a := 0; a < adders; a++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < addsEach; i++ {
+				cart.AddItem(&Book{Name: fmt.Sprintf("Book %d", i%5), Price: 10, Pages: 100})
+			}
+			cart.AddItem(&Clothing{Name: "Scarf", Price: 15})
+			cart.RemoveItem("Scarf")
+		}()
+		go func() {
+			defer wg.Done()
+			counter := &unitCounter{} // visitors hold state, so one per goroutine
+			cart.ApplyVisitor(counter)
+			cart.ApplyVisitor(NewTaxCalculator(nil, "$"))
+			cart.GetTotalPrice()
+			cart.Count()
+		}()
+	}
+	wg.Wait()
+
+	final := &unitCounter{}
+	cart.ApplyVisitor(final)
+	if want := adders * addsEach; final.units != want || cart.Count() != want {
+		t.Errorf("visited %d units, Count() = %d, want %d", final.units, cart.Count(), want)
+	}
+	if got := len(cart.Lines()); got != 5 {
+		t.Errorf("got %d lines, want 5: one per distinct book", got)
+	}
+	if want := float64(adders*addsEach) * 10; !approxEqual(cart.GetTotalPrice(), want) {
+		t.Errorf("GetTotalPrice() = %v, want %v", cart.GetTotalPrice(), want)
+	}
+}
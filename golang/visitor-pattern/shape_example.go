@@ -1,8 +1,12 @@
 package main
 
 import (
+	"bytes"
+	"encoding/json"
 	"fmt"
+	"io"
 	"math"
+	"strings"
 )
 
 // ============================================================================
@@ -182,6 +186,64 @@ func (j *JSONExporter) GetJSON() string {
 	return json
 }
 
+// point is a coordinate pair in NDJSON output
+type point struct {
+	X float64 `json:"x"`
+	Y float64 `json:"y"`
+}
+
+// NDJSONExporter writes each shape as one JSON object per line (newline
+// delimited JSON) as soon as it is visited, instead of collecting them all in
+// memory like JSONExporter. The first write error stops further output and is
+// reported by Err.
+type NDJSONExporter struct {
+	encoder *json.Encoder
+	err     error
+}
+
+// NewNDJSONExporter returns an exporter that writes to w
+func NewNDJSONExporter(w io.Writer) *NDJSONExporter {
+	return &NDJSONExporter{encoder: json.NewEncoder(w)}
+}
+
+// write encodes v followed by a newline
+func (n *NDJSONExporter) write(v any) {
+	if n.err == nil {
+		n.err = n.encoder.Encode(v)
+	}
+}
+
+func (n *NDJSONExporter) VisitCircle(c *Circle) {
+	n.write(struct {
+		Type   string  `json:"type"`
+		Radius float64 `json:"radius"`
+		Center point   `json:"center"`
+	}{"circle", c.Radius, point{c.X, c.Y}})
+}
+
+func (n *NDJSONExporter) VisitRectangle(r *Rectangle) {
+	n.write(struct {
+		Type     string  `json:"type"`
+		Width    float64 `json:"width"`
+		Height   float64 `json:"height"`
+		Position point   `json:"position"`
+	}{"rectangle", r.Width, r.Height, point{r.X, r.Y}})
+}
+
+func (n *NDJSONExporter) VisitTriangle(t *Triangle) {
+	n.write(struct {
+		Type     string  `json:"type"`
+		Base     float64 `json:"base"`
+		Height   float64 `json:"height"`
+		Position point   `json:"position"`
+	}{"triangle", t.Base, t.Height, point{t.X, t.Y}})
+}
+
+// Err returns the first error hit while writing, if any
+func (n *NDJSONExporter) Err() error {
+	return n.err
+}
+
 // ============================================================================
 // DRAWING - Client Code
 // ============================================================================
@@ -282,6 +344,22 @@ func main() {
 	fmt.Println(jsonExporter.GetJSON())
 	fmt.Println()
 
+	// Stream as NDJSON
+	fmt.Println("📋 NDJSON EXPORT:")
+	fmt.Println("─────────────────────────────────────────────────────────")
+	var buf bytes.Buffer
+	ndjsonExporter := NewNDJSONExporter(&buf)
+	drawing.ApplyVisitor(ndjsonExporter)
+	if err := ndjsonExporter.Err(); err != nil {
+		fmt.Println("NDJSON export failed:", err)
+	}
+	for i, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		var shape map[string]any
+		err := json.Unmarshal([]byte(line), &shape)
+		fmt.Printf("  line %d parses: %v (%v) %s\n", i+1, err == nil, shape["type"], line)
+	}
+	fmt.Println()
+
 	fmt.Println("✨ Key Takeaway:")
 	fmt.Println("   We performed 4 different operations (Area, Perimeter, SVG, JSON)")
 	fmt.Println("   on 3 shape types without modifying the shape classes!")
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestNDJSONExporterWritesOneObjectPerLine(t *testing.T) {
+	drawing := &Drawing{Name: "test"}
+	drawing.AddShape(&Circle{Radius: 5, X: 1, Y: 2})
+	drawing.AddShape(&Rectangle{Width: 3, Height: 4, X: 5, Y: 6})
+	drawing.AddShape(&Triangle{Base: 7, Height: 8, X: 9, Y: 10})
+
+	var buf bytes.Buffer
+	exporter := NewNDJSONExporter(&buf)
+	drawing.ApplyVisitor(exporter)
+	if err := exporter.Err(); err != nil {
+		t.Fatalf("Err() = %v, want nil", err)
+	}
+
+	want := []map[string]any{
+		{"type": "circle", "radius": 5.0, "center": map[string]any{"x": 1.0, "y": 2.0}},
+		{"type": "rectangle", "width": 3.0, "height": 4.0, "position": map[string]any{"x": 5.0, "y": 6.0}},
+		{"type": "triangle", "base": 7.0, "height": 8.0, "position": map[string]any{"x": 9.0, "y": 10.0}},
+	}
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	if len(lines) != len(want) {
+		t.Fatalf("got %d lines, want %d:\n%s", len(lines), len(want), buf.String())
+	}
+	for i, line := range lines {
+		var got map[string]any
+		if err := json.Unmarshal([]byte(line), &got); err != nil {
+			t.Errorf("line %d %q does not parse on its own: %v", i, line, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, want[i]) {
+			t.Errorf("line %d = %v, want %v", i, got, want[i])
+		}
+	}
+}
+
+// failingWriter rejects every write
+type failingWriter struct{ writes int }
+
+func (f *failingWriter) Write([]byte) (int, error) {
+	f.writes++
+	return 0, errors.New("disk full")
+}
+
+func TestNDJSONExporterStopsAtFirstError(t *testing.T) {
+	w := &failingWriter{}
+	exporter := NewNDJSONExporter(w)
+	exporter.VisitCircle(&Circle{Radius: 1})
+	exporter.VisitCircle(&Circle{Radius: 2})
+
+	if exporter.Err() == nil {
+		t.Error("Err() = nil, want the write error")
+	}
+	if w.writes != 1 {
+		t.Errorf("writer called %d times, want 1", w.writes)
+	}
+}